@@ -1,10 +1,115 @@
 package interfaces
 
 import (
+	"context"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
 	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
 )
 
+// UseCase is the application layer used by the HTTP controllers
 type UseCase interface {
-	SearchFlights(req *DTO.FlightSearchRequest) (*DTO.FlightSearchResponse, error)
-	GetToken() error
+	SearchFlights(ctx context.Context, req *DTO.FlightSearchRequest) (*DTO.FlightSearchResponse, error)
+	RevalidateItinerary(ctx context.Context, req *DTO.RevalidateRequest) (*DTO.RevalidateResponse, error)
+	GetSeatMap(ctx context.Context, req *DTO.SeatMapRequest) (*DTO.SeatMapResponse, error)
+	GetFareRules(ctx context.Context, req *DTO.FareRulesRequest) (*DTO.FareRulesResponse, error)
+	GetOffer(ctx context.Context, itineraryID string) (*DTO.OfferResponse, error)
+}
+
+// BookingUseCase is the application layer used by the booking controllers
+type BookingUseCase interface {
+	CreateBooking(ctx context.Context, req *DTO.BookingRequest) (*DTO.BookingResponse, error)
+	GetBooking(ctx context.Context, locator string) (*DTO.BookingResponse, error)
+	CancelBooking(ctx context.Context, req *DTO.CancelBookingRequest) (*DTO.BookingResponse, error)
+	IssueTickets(ctx context.Context, req *DTO.TicketRequest) (*DTO.BookingResponse, error)
+	VoidTickets(ctx context.Context, locator string) (*DTO.BookingResponse, error)
+}
+
+// LocationUseCase is the application layer used by the location controllers
+type LocationUseCase interface {
+	SearchLocations(ctx context.Context, req *DTO.LocationSearchRequest) (*DTO.LocationSearchResponse, error)
+}
+
+// FlightProvider is implemented by every content source (GDS or NDC adapter)
+type FlightProvider interface {
+	Name() string
+	SearchFlights(ctx context.Context, query *domain.SearchQuery) (*domain.SearchResult, error)
+}
+
+// Revalidator re-prices a selected itinerary against live availability
+type Revalidator interface {
+	Revalidate(ctx context.Context, sel *domain.ItinerarySelection) (*domain.SearchResult, error)
+}
+
+// SeatMapProvider returns the seat layout of a flight
+type SeatMapProvider interface {
+	SeatMap(ctx context.Context, query *domain.SeatMapQuery) (*domain.SeatMap, error)
+}
+
+// FareRulesProvider returns the rules of a fare component
+type FareRulesProvider interface {
+	FareRules(ctx context.Context, query *domain.FareRulesQuery) (*domain.FareRules, error)
+}
+
+// RateSource supplies the exchange rates used to convert prices
+type RateSource interface {
+	Rates(ctx context.Context) (*domain.RateTable, error)
+}
+
+// LocationDirectory looks up airports and cities in the reference data
+type LocationDirectory interface {
+	Lookup(code string) (*domain.Location, bool)
+	City(code string) (*domain.Location, bool)
+	Search(query string, limit int) []domain.Location
+}
+
+// AirportTimes places local airport times on the calendar using the airports' time zones
+type AirportTimes interface {
+	AirportTime(code, date, clock string) (time.Time, error)
+}
+
+// AirlineDirectory looks up airlines in the reference data
+type AirlineDirectory interface {
+	Airline(code string) (*domain.Airline, bool)
+}
+
+// AircraftDirectory looks up aircraft types in the reference data
+type AircraftDirectory interface {
+	Aircraft(code string) (*domain.AircraftType, bool)
+}
+
+// EmissionsEstimator estimates the CO2 emitted by the flights of an offer
+type EmissionsEstimator interface {
+	Estimate(offer *domain.Offer)
+}
+
+// OfferStore keeps snapshots of returned offers for a limited time
+type OfferStore interface {
+	Save(ctx context.Context, snapshot *domain.OfferSnapshot) error
+	Get(ctx context.Context, itineraryID string) (*domain.OfferSnapshot, error)
+}
+
+// BookingProvider creates and manages reservations
+type BookingProvider interface {
+	Name() string
+	CreateBooking(ctx context.Context, req *domain.BookingRequest) (*domain.Booking, error)
+	GetBooking(ctx context.Context, locator string) (*domain.Booking, error)
+	CancelBooking(ctx context.Context, locator string, segmentIDs []string) (*domain.Booking, error)
+}
+
+// TicketingProvider issues and voids the tickets of a reservation
+type TicketingProvider interface {
+	IssueTickets(ctx context.Context, req *domain.TicketingRequest) ([]domain.Ticket, error)
+	VoidTickets(ctx context.Context, locator string, numbers []string) error
+	// TicketingTimeZone is the time zone tickets are issued in; the void window closes at its midnight
+	TicketingTimeZone() *time.Location
+}
+
+// LockStore grants exclusive, expiring locks shared by every instance of the service
+type LockStore interface {
+	// Acquire takes the lock on key until it is released or ttl passes; ok is false while another holder has it
+	Acquire(ctx context.Context, key string, ttl time.Duration) (token string, ok bool, err error)
+	// Release gives back a lock taken with the token Acquire returned
+	Release(ctx context.Context, key, token string) error
 }
@@ -2,44 +2,225 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// Supported flight content providers
+const (
+	ProviderSabre   = "sabre"
+	ProviderAmadeus = "amadeus"
+)
+
+// DefaultProviderTimeout bounds each provider call when PROVIDER_TIMEOUT is unset
+const DefaultProviderTimeout = 20 * time.Second
+
+// Defaults for the exchange rates file used to convert prices
+const (
+	DefaultRatesFile           = "rates.json"
+	DefaultRatesReloadInterval = 10 * time.Minute
+)
+
+// DefaultOfferTTL is how long offers can be referred to by ID when OFFER_TTL is unset
+const DefaultOfferTTL = 30 * time.Minute
+
+// DefaultMarkupRulesFile is the markup rules file used when MARKUP_RULES_FILE is unset
+const DefaultMarkupRulesFile = "markup_rules.json"
+
 // Config holds the application configuration
 type Config struct {
-	// Server configuration
+	// Providers lists the content sources queried by every search
+	Providers []string
+	// ProviderTimeout bounds the time a single provider may take to answer
+	ProviderTimeout time.Duration
+
+	// RatesFile is the JSON file exchange rates are read from
+	RatesFile string
+	// RatesReloadInterval is how often the rates file is checked for changes
+	RatesReloadInterval time.Duration
+
+	// MarkupRulesFile is the versioned JSON file markup rules are read from
+	MarkupRulesFile string
+
+	// OfferTTL is how long an offer from a search can be looked up by its itinerary ID
+	OfferTTL time.Duration
+
+	// AirlinesFile and AircraftFile replace the embedded reference data when set
+	AirlinesFile string
+	AircraftFile string
+
+	// Sabre configuration
 	ClientID     string
 	ClientSecret string
-	PCC          string
+	PCC          string         // First configured Pseudo City Code
+	PCCs         []string       // Every configured Pseudo City Code, searched in parallel
+	PCCTimeZone  *time.Location // Where the PCCs issue tickets; bounds the void window
 	URL          string
 	SABREAUTHURL string
+	SabreBaseURL string // Root of Sabre's REST APIs; derived from URL when unset
+
+	// Amadeus configuration
+	AmadeusClientID     string
+	AmadeusClientSecret string
+	AmadeusURL          string
+	AmadeusFake         bool // Serve Amadeus searches from the built-in fake API; for development only
 }
 
 // New returns a new Config instance
 func New() (*Config, error) {
 	c := &Config{
-		ClientID:     os.Getenv("CLIENTID"),
-		ClientSecret: os.Getenv("CLIENTSECRET"),
-		PCC:          os.Getenv("PCC"),
-		URL:          os.Getenv("URL"),
-		SABREAUTHURL: os.Getenv("SABREAUTHURL"),
+		Providers:           splitList(os.Getenv("PROVIDERS")),
+		ProviderTimeout:     DefaultProviderTimeout,
+		RatesFile:           DefaultRatesFile,
+		RatesReloadInterval: DefaultRatesReloadInterval,
+		MarkupRulesFile:     DefaultMarkupRulesFile,
+		OfferTTL:            DefaultOfferTTL,
+		AirlinesFile:        os.Getenv("AIRLINES_FILE"),
+		AircraftFile:        os.Getenv("AIRCRAFT_FILE"),
+		ClientID:            os.Getenv("CLIENTID"),
+		ClientSecret:        os.Getenv("CLIENTSECRET"),
+		PCCs:                splitList(os.Getenv("PCC")),
+		URL:                 os.Getenv("URL"),
+		SABREAUTHURL:        os.Getenv("SABREAUTHURL"),
+		SabreBaseURL:        os.Getenv("SABREBASEURL"),
+		AmadeusClientID:     os.Getenv("AMADEUS_CLIENTID"),
+		AmadeusClientSecret: os.Getenv("AMADEUS_CLIENTSECRET"),
+		AmadeusURL:          os.Getenv("AMADEUS_URL"),
+		PCCTimeZone:         time.UTC,
+	}
+
+	// PROVIDER is the single-provider setting that predates PROVIDERS
+	if len(c.Providers) == 0 {
+		c.Providers = splitList(os.Getenv("PROVIDER"))
+	}
+	if len(c.Providers) == 0 {
+		c.Providers = []string{ProviderSabre}
+	}
+	if len(c.PCCs) > 0 {
+		c.PCC = c.PCCs[0]
+	}
+
+	if raw := os.Getenv("PROVIDER_TIMEOUT"); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("PROVIDER_TIMEOUT must be a positive duration such as 15s")
+		}
+		c.ProviderTimeout = timeout
+	}
+
+	if raw := os.Getenv("RATES_FILE"); raw != "" {
+		c.RatesFile = raw
+	}
+	if raw := os.Getenv("RATES_RELOAD_INTERVAL"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("RATES_RELOAD_INTERVAL must be a positive duration such as 10m")
+		}
+		c.RatesReloadInterval = interval
 	}
 
+	if raw := os.Getenv("MARKUP_RULES_FILE"); raw != "" {
+		c.MarkupRulesFile = raw
+	}
+
+	if raw := os.Getenv("PCC_TIMEZONE"); raw != "" {
+		zone, err := time.LoadLocation(raw)
+		if err != nil {
+			return nil, fmt.Errorf("PCC_TIMEZONE must be an IANA time zone such as America/New_York")
+		}
+		c.PCCTimeZone = zone
+	}
+
+	if raw := os.Getenv("AMADEUS_FAKE"); raw != "" {
+		fake, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("AMADEUS_FAKE must be true or false")
+		}
+		c.AmadeusFake = fake
+	}
+
+	if raw := os.Getenv("OFFER_TTL"); raw != "" {
+		ttl, err := time.ParseDuration(raw)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("OFFER_TTL must be a positive duration such as 30m")
+		}
+		c.OfferTTL = ttl
+	}
+
+	for _, provider := range c.Providers {
+		switch provider {
+		case ProviderSabre:
+			if err := c.validateSabre(); err != nil {
+				return nil, err
+			}
+		case ProviderAmadeus:
+			if err := c.validateAmadeus(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown provider %q", provider)
+		}
+	}
+
+	return c, nil
+}
+
+// validateSabre checks the settings required to talk to Sabre
+func (c *Config) validateSabre() error {
 	if c.ClientID == "" {
-		return nil, fmt.Errorf("CLIENT_ID is required")
+		return fmt.Errorf("CLIENT_ID is required")
 	}
 	if c.ClientSecret == "" {
-		return nil, fmt.Errorf("CLIENT_SECRET is required")
+		return fmt.Errorf("CLIENT_SECRET is required")
 	}
 	if c.PCC == "" {
-		return nil, fmt.Errorf("PCC is required")
+		return fmt.Errorf("PCC is required")
 	}
 	if c.SABREAUTHURL == "" {
-		return nil, fmt.Errorf("SABREAUTHURL is required")
+		return fmt.Errorf("SABREAUTHURL is required")
 	}
 	if c.URL == "" {
-		return nil, fmt.Errorf("URL is required")
+		return fmt.Errorf("URL is required")
+	}
+	if c.SabreBaseURL == "" {
+		parsed, err := url.Parse(c.URL)
+		if err != nil || parsed.Host == "" {
+			return fmt.Errorf("URL must be an absolute URL")
+		}
+		c.SabreBaseURL = parsed.Scheme + "://" + parsed.Host
 	}
+	c.SabreBaseURL = strings.TrimSuffix(c.SabreBaseURL, "/")
+	return nil
+}
 
-	return c, nil
+// validateAmadeus checks the settings required to talk to Amadeus
+// The fake API needs none, but must be asked for explicitly.
+func (c *Config) validateAmadeus() error {
+	if c.AmadeusFake {
+		if c.AmadeusURL != "" {
+			return fmt.Errorf("AMADEUS_URL and AMADEUS_FAKE cannot both be set")
+		}
+		return nil
+	}
+	if c.AmadeusURL == "" {
+		return fmt.Errorf("AMADEUS_URL is required; set AMADEUS_FAKE=true to use the fake Amadeus API")
+	}
+	if c.AmadeusClientID == "" || c.AmadeusClientSecret == "" {
+		return fmt.Errorf("AMADEUS_CLIENTID and AMADEUS_CLIENTSECRET are required")
+	}
+	return nil
+}
+
+// splitList parses a comma separated setting, dropping empty entries
+func splitList(raw string) []string {
+	var out []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
 }
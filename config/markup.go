@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// MarkupRulesVersion is the rules file format this build understands
+const MarkupRulesVersion = 1
+
+// LoadMarkupRules reads and checks a markup rules file
+// A missing file yields an empty rule set so markup stays optional.
+// Args:
+//
+//	path - Location of the rules file
+//
+// Returns:
+//
+//	Pointer to the MarkupRuleSet or an error if the file is invalid
+func LoadMarkupRules(path string) (*domain.MarkupRuleSet, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &domain.MarkupRuleSet{Version: MarkupRulesVersion}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read markup rules: %v", err)
+	}
+
+	var rules domain.MarkupRuleSet
+	if err := json.Unmarshal(raw, &rules); err != nil {
+		return nil, fmt.Errorf("invalid markup rules file %s: %v", path, err)
+	}
+	if rules.Version != MarkupRulesVersion {
+		return nil, fmt.Errorf("markup rules file %s has version %d, expected %d", path, rules.Version, MarkupRulesVersion)
+	}
+
+	ids := make(map[string]bool, len(rules.Rules))
+	for i, rule := range rules.Rules {
+		if rule.ID == "" {
+			return nil, fmt.Errorf("markup rule %d has no id", i+1)
+		}
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("markup rule %q is defined twice", rule.ID)
+		}
+		ids[rule.ID] = true
+		if rule.Type != domain.MarkupTypeMarkup && rule.Type != domain.MarkupTypeDiscount {
+			return nil, fmt.Errorf("markup rule %q: type must be markup or discount", rule.ID)
+		}
+		if rule.Percentage < 0 || rule.Fixed < 0 {
+			return nil, fmt.Errorf("markup rule %q: amounts must not be negative", rule.ID)
+		}
+		// Absolute amounts only mean something in one currency
+		if (rule.Fixed != 0 || rule.Min != nil || rule.Max != nil) && rule.Currency == "" {
+			return nil, fmt.Errorf("markup rule %q: currency is required with a fixed amount, min or max", rule.ID)
+		}
+		if (rule.Min != nil && *rule.Min < 0) || (rule.Max != nil && *rule.Max < 0) {
+			return nil, fmt.Errorf("markup rule %q: amounts must not be negative", rule.ID)
+		}
+		for _, cabin := range rule.Cabins {
+			switch cabin {
+			case domain.CabinEconomy, domain.CabinPremiumEconomy, domain.CabinBusiness, domain.CabinFirst:
+			default:
+				return nil, fmt.Errorf("markup rule %q: cabin %q must be one of Y, S, C or F", rule.ID, cabin)
+			}
+		}
+		if rule.Min != nil && rule.Max != nil && *rule.Min > *rule.Max {
+			return nil, fmt.Errorf("markup rule %q: min is greater than max", rule.ID)
+		}
+	}
+	return &rules, nil
+}
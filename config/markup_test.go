@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeRules writes a rules file into a temporary directory and returns its path
+func writeRules(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "markup_rules.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing rules: %v", err)
+	}
+	return path
+}
+
+func TestLoadMarkupRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{name: "percentage without currency", rules: `{"id": "a", "type": "markup", "percentage": 2}`},
+		{name: "caps with currency", rules: `{"id": "a", "type": "markup", "percentage": 2, "min": 5, "max": 40, "currency": "USD"}`},
+		{name: "cabins", rules: `{"id": "a", "type": "markup", "percentage": 2, "cabins": ["Y", "S", "C", "F"]}`},
+		{name: "fixed without currency", rules: `{"id": "a", "type": "markup", "fixed": 5}`, wantErr: "currency is required"},
+		{name: "min without currency", rules: `{"id": "a", "type": "markup", "percentage": 2, "min": 25}`, wantErr: "currency is required"},
+		{name: "max without currency", rules: `{"id": "a", "type": "discount", "percentage": 2, "max": 25}`, wantErr: "currency is required"},
+		{name: "min above max", rules: `{"id": "a", "type": "markup", "min": 50, "max": 25, "currency": "USD"}`, wantErr: "min is greater than max"},
+		{name: "negative cap", rules: `{"id": "a", "type": "markup", "min": -5, "currency": "USD"}`, wantErr: "must not be negative"},
+		{name: "unknown cabin", rules: `{"id": "a", "type": "markup", "percentage": 2, "cabins": ["W"]}`, wantErr: `cabin "W"`},
+		{name: "unknown type", rules: `{"id": "a", "type": "bonus", "percentage": 2}`, wantErr: "type must be markup or discount"},
+		{name: "missing id", rules: `{"type": "markup", "percentage": 2}`, wantErr: "has no id"},
+		{name: "duplicate id", rules: `{"id": "a", "type": "markup"}, {"id": "a", "type": "markup"}`, wantErr: "defined twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeRules(t, `{"version": 1, "rules": [`+tt.rules+`]}`)
+			_, err := LoadMarkupRules(path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadMarkupRulesVersion(t *testing.T) {
+	if _, err := LoadMarkupRules(writeRules(t, `{"version": 2, "rules": []}`)); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+	rules, err := LoadMarkupRules(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || len(rules.Rules) != 0 {
+		t.Errorf("missing file = %+v, %v; want an empty rule set", rules, err)
+	}
+}
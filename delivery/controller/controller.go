@@ -1,27 +1,37 @@
 package controller
 
 import (
+	"errors"
+	"net/http"
+
 	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces" // Package defining use case interfaces
+	"github.com/Yordi-SE/FlightSearch/domain"                // Package containing provider-neutral errors
 	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"      // Package containing data transfer objects
 	"github.com/gin-gonic/gin"                               // Gin web framework for HTTP handling
 )
 
 // Controller handles HTTP requests related to flight searches
 type Controller struct {
-	FlightClient interfaces.UseCase // Interface for interacting with flight search use case
+	FlightClient interfaces.UseCase         // Interface for interacting with flight search use case
+	Bookings     interfaces.BookingUseCase  // Interface for interacting with booking use case
+	Locations    interfaces.LocationUseCase // Interface for interacting with location use case
 }
 
 // NewController creates and initializes a new Controller instance
 // Args:
 //
 //	client - An implementation of the UseScase interface for flight operations
+//	bookings - An implementation of the BookingUseCase interface for reservations
+//	locations - An implementation of the LocationUseCase interface for reference data
 //
 // Returns:
 //
 //	Pointer to a new Controller instance
-func NewController(client interfaces.UseCase) *Controller {
+func NewController(client interfaces.UseCase, bookings interfaces.BookingUseCase, locations interfaces.LocationUseCase) *Controller {
 	return &Controller{
-		FlightClient: client, // Inject the flight client dependency
+		FlightClient: client,    // Inject the flight client dependency
+		Bookings:     bookings,  // Inject the booking dependency
+		Locations:    locations, // Inject the location dependency
 	}
 }
 
@@ -49,15 +59,299 @@ func (ctrl *Controller) SearchFlights(c *gin.Context) {
 	}
 
 	// Call the use case to search for flights
-	result, err := ctrl.FlightClient.SearchFlights(&req)
+	result, err := ctrl.FlightClient.SearchFlights(c.Request.Context(), &req)
 	// fmt.Println(result) // Log the result for debugging purposes
 
-	// If the search fails, return 500 Internal Server Error
+	// If the search fails, map the error onto a status (500 unless the request was at fault)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	// Success: return 200 OK with the flight search results
 	c.JSON(200, result)
 }
+
+// RevalidateItinerary handles the HTTP POST request to re-price a selected itinerary
+// It returns the current price together with a confirmed, price_changed or
+// unavailable status
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) RevalidateItinerary(c *gin.Context) {
+	var req DTO.RevalidateRequest
+
+	// Bind JSON request body to RevalidateRequest struct
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Validate the request data (e.g., passenger mix)
+	if err := req.Validate(); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.FlightClient.RevalidateItinerary(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// GetSeatMap handles the HTTP POST request for the seat layout of a segment
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) GetSeatMap(c *gin.Context) {
+	var req DTO.SeatMapRequest
+
+	// Bind JSON request body to SeatMapRequest struct
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.FlightClient.GetSeatMap(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// GetFareRules handles the HTTP POST request for the rules of an itinerary's fares
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) GetFareRules(c *gin.Context) {
+	var req DTO.FareRulesRequest
+
+	// Bind JSON request body to FareRulesRequest struct
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.FlightClient.GetFareRules(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// GetOffer handles the HTTP GET request for an offer from a recent search
+// The offer is returned as the search showed it, which makes it shareable by link
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) GetOffer(c *gin.Context) {
+	id := c.Param("id")
+	if err := DTO.ValidateItineraryID(id); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.FlightClient.GetOffer(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// CreateBooking handles the HTTP POST request to book a selected itinerary
+// It validates the travelers against the searched passengers and returns the
+// record locator and ticketing time limit of the held reservation
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) CreateBooking(c *gin.Context) {
+	var req DTO.BookingRequest
+
+	// Bind JSON request body to BookingRequest struct
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Validate the travelers against the passenger mix and travel dates
+	if err := req.Validate(); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.Bookings.CreateBooking(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(201, result)
+}
+
+// GetBooking handles the HTTP GET request to retrieve a reservation
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) GetBooking(c *gin.Context) {
+	locator := c.Param("pnr")
+	if err := DTO.ValidateRecordLocator(locator); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.Bookings.GetBooking(c.Request.Context(), locator)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// CancelBooking handles the HTTP DELETE request to cancel a reservation
+// Individual segments are cancelled by passing their IDs as repeated
+// segment query parameters; without any the whole reservation is cancelled
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) CancelBooking(c *gin.Context) {
+	var req DTO.CancelBookingRequest
+
+	// Bind the record locator from the path and the segments from the query
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.Bookings.CancelBooking(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// IssueTickets handles the HTTP POST request to ticket a held reservation
+// Retrying the request returns the already issued tickets instead of ticketing again
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) IssueTickets(c *gin.Context) {
+	var req DTO.TicketRequest
+
+	// Bind the form of payment from the body and the record locator from the path
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.Bookings.IssueTickets(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// VoidTickets handles the HTTP POST request to void the tickets of a reservation
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) VoidTickets(c *gin.Context) {
+	locator := c.Param("pnr")
+	if err := DTO.ValidateRecordLocator(locator); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.Bookings.VoidTickets(c.Request.Context(), locator)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// SearchLocations handles the HTTP GET request to autocomplete airports and cities
+// Matches on the IATA code rank first, then airport names, then city names
+// Args:
+//
+//	c - Gin context containing the HTTP request and response
+func (ctrl *Controller) SearchLocations(c *gin.Context) {
+	var req DTO.LocationSearchRequest
+
+	// Bind the query string to LocationSearchRequest struct
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.Locations.SearchLocations(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// errorStatus maps use case errors onto HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrUnsupportedCurrency), errors.Is(err, domain.ErrUnknownLocation),
+		errors.Is(err, domain.ErrOfferMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrNotSupported):
+		return http.StatusNotImplemented
+	case errors.Is(err, domain.ErrBookingNotFound), errors.Is(err, domain.ErrSeatMapUnavailable),
+		errors.Is(err, domain.ErrOfferNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrNoAvailability), errors.Is(err, domain.ErrBookingState),
+		errors.Is(err, domain.ErrVoidWindowClosed):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrNoProviders):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
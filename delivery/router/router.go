@@ -7,11 +7,21 @@ import (
 )
 
 // New returns a new Router instance
-func NewRouter(FlightClient interfaces.UseCase) {
+func NewRouter(FlightClient interfaces.UseCase, Bookings interfaces.BookingUseCase, Locations interfaces.LocationUseCase) {
 	router := gin.Default()
 
-	Controller := controller.NewController(FlightClient)
+	Controller := controller.NewController(FlightClient, Bookings, Locations)
 	router.POST("/flight/search", Controller.SearchFlights)
+	router.POST("/flight/revalidate", Controller.RevalidateItinerary)
+	router.POST("/flight/seatmap", Controller.GetSeatMap)
+	router.POST("/flight/farerules", Controller.GetFareRules)
+	router.GET("/flight/offers/:id", Controller.GetOffer)
+	router.POST("/booking", Controller.CreateBooking)
+	router.GET("/booking/:pnr", Controller.GetBooking)
+	router.DELETE("/booking/:pnr", Controller.CancelBooking)
+	router.POST("/booking/:pnr/ticket", Controller.IssueTickets)
+	router.POST("/booking/:pnr/void", Controller.VoidTickets)
+	router.GET("/locations", Controller.SearchLocations)
 	router.Run(":8080")
 
 }
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxPricedBags is the highest number of checked bags a baggage-inclusive price is computed for
+// Providers only itemize the fees of the first and second bag.
+const MaxPricedBags = 2
+
+// kilogramsPerPound converts allowances filed in pounds
+const kilogramsPerPound = 0.45359237
+
+// weightPattern finds a weight limit in free-text allowance descriptions such as
+// "UP TO 50 POUNDS/23 KILOGRAMS"
+var weightPattern = regexp.MustCompile(`(\d+)\s*(KG|KGS|KILOGRAMS?|LB|LBS|POUNDS?)\b`)
+
+// BaggagePolicy is the baggage policy of one passenger type on one leg
+// It is derived from the allowances and charges on the leg's segments; when the
+// segments differ the most restrictive allowance applies.
+type BaggagePolicy struct {
+	Leg             int    `json:"leg"` // Index of the leg in the offer
+	PassengerType   string `json:"passenger_type"`
+	PassengerCount  int    `json:"passenger_count"`
+	CheckedBags     *int   `json:"checked_bags,omitempty"`      // Included checked pieces; unset when unknown or by weight only
+	CheckedWeightKg *int   `json:"checked_weight_kg,omitempty"` // Per piece for piece allowances, in total otherwise
+	CarryOnBags     *int   `json:"carry_on_bags,omitempty"`
+	FirstBagFee     *Money `json:"first_bag_fee,omitempty"`  // First checked bag beyond the included ones
+	SecondBagFee    *Money `json:"second_bag_fee,omitempty"` // Second checked bag beyond the included ones
+}
+
+// BaggagePrice is an offer's total with a number of checked bags for every passenger on every leg
+type BaggagePrice struct {
+	CheckedBags int     `json:"checked_bags"`
+	BaggageFees float64 `json:"baggage_fees"` // Fees for the bags beyond the included ones
+	Total       float64 `json:"total"`
+	Currency    string  `json:"currency"`
+	// Complete is false when a policy or fee was unknown; Total then only counts the known fees
+	Complete bool `json:"complete"`
+}
+
+// ComputeBaggagePolicies normalizes the baggage data of the segments into one
+// policy per leg and passenger type
+func (o *Offer) ComputeBaggagePolicies() {
+	o.BaggagePolicies = nil
+	for i, leg := range o.Legs {
+		index := make(map[string]int)
+		for _, seg := range leg.Segments {
+			for _, passenger := range seg.Passengers {
+				n, ok := index[passenger.PassengerType]
+				if !ok {
+					n = len(o.BaggagePolicies)
+					index[passenger.PassengerType] = n
+					o.BaggagePolicies = append(o.BaggagePolicies, BaggagePolicy{
+						Leg:            i,
+						PassengerType:  passenger.PassengerType,
+						PassengerCount: passenger.PassengerNumber,
+					})
+				}
+				policy := &o.BaggagePolicies[n]
+				for _, allowance := range passenger.Baggage {
+					policy.addAllowance(allowance)
+				}
+				for _, charge := range passenger.BaggageCharges {
+					policy.addCharge(charge)
+				}
+			}
+		}
+	}
+}
+
+// addAllowance narrows the policy with a segment's allowance
+func (p *BaggagePolicy) addAllowance(a BaggageAllowance) {
+	if a.CarryOn {
+		p.CarryOnBags = minPointer(p.CarryOnBags, a.Pieces)
+		return
+	}
+	p.CheckedBags = minPointer(p.CheckedBags, a.Pieces)
+	p.CheckedWeightKg = minPointer(p.CheckedWeightKg, allowanceWeightKg(a))
+}
+
+// addCharge records the fees of the first and second extra checked bag
+// The first segment charging for a bag sets its fee.
+func (p *BaggagePolicy) addCharge(c BaggageCharge) {
+	if c.CarryOn {
+		return
+	}
+	last := max(c.LastPiece, c.FirstPiece)
+	fee := Money{Amount: c.Amount, Currency: c.Currency}
+	if p.FirstBagFee == nil && c.FirstPiece <= 1 && last >= 1 {
+		p.FirstBagFee = &fee
+	}
+	if p.SecondBagFee == nil && c.FirstPiece <= 2 && last >= 2 {
+		p.SecondBagFee = &fee
+	}
+}
+
+// bagFee returns the fee of the n-th checked bag beyond the included ones
+func (p *BaggagePolicy) bagFee(n int) *Money {
+	switch n {
+	case 1:
+		return p.FirstBagFee
+	case 2:
+		return p.SecondBagFee
+	}
+	return nil
+}
+
+// PriceWithCheckedBags returns the offer's total when every passenger checks bags on every leg
+// Bags within the allowance are free; the others are priced from the policies.
+// Args:
+//
+//	bags - Checked bags per passenger and leg, at most MaxPricedBags
+//
+// Returns:
+//
+//	The baggage-inclusive price in the offer's currency
+func (o *Offer) PriceWithCheckedBags(bags int) *BaggagePrice {
+	price := &BaggagePrice{CheckedBags: bags, Currency: o.Price.Currency, Complete: len(o.BaggagePolicies) > 0}
+	for i := range o.BaggagePolicies {
+		policy := &o.BaggagePolicies[i]
+		if policy.CheckedBags == nil {
+			price.Complete = false
+			continue
+		}
+		for n := 1; n <= bags-*policy.CheckedBags; n++ {
+			fee := policy.bagFee(n)
+			if fee == nil || fee.Currency != o.Price.Currency {
+				price.Complete = false
+				continue
+			}
+			price.BaggageFees += fee.Amount * float64(max(policy.PassengerCount, 1))
+		}
+	}
+	price.BaggageFees = RoundAmount(price.BaggageFees, price.Currency)
+	price.Total = RoundAmount(o.Price.Total+price.BaggageFees, price.Currency)
+	return price
+}
+
+// allowanceWeightKg returns an allowance's weight limit in kilograms
+// Allowances without a structured weight fall back to their description.
+func allowanceWeightKg(a BaggageAllowance) *int {
+	if a.Weight != nil {
+		kg := weightKg(*a.Weight, a.Unit)
+		return &kg
+	}
+	for _, line := range a.Description {
+		for _, m := range weightPattern.FindAllStringSubmatch(strings.ToUpper(line), -1) {
+			weight, err := strconv.Atoi(m[1])
+			if err != nil {
+				continue
+			}
+			kg := weightKg(weight, m[2])
+			return &kg
+		}
+	}
+	return nil
+}
+
+// weightKg converts a weight in the given unit to whole kilograms
+// Units other than pounds are taken to be kilograms.
+func weightKg(weight int, unit string) int {
+	switch strings.ToUpper(unit) {
+	case "L", "LB", "LBS", "POUND", "POUNDS":
+		return int(math.Round(float64(weight) * kilogramsPerPound))
+	}
+	return weight
+}
+
+// minPointer returns the smaller of two optional values, ignoring unset ones
+func minPointer(a, b *int) *int {
+	if a == nil {
+		return b
+	}
+	if b == nil || *a <= *b {
+		return a
+	}
+	return b
+}
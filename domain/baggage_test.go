@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+// formatOptional prints an optional value, or "-" when it is unset
+func formatOptional(n *int) string {
+	if n == nil {
+		return "-"
+	}
+	return fmt.Sprint(*n)
+}
+
+// formatFee prints an optional fee, or "-" when it is unset
+func formatFee(m *Money) string {
+	if m == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%g %s", m.Amount, m.Currency)
+}
+
+func TestAllowanceWeightKg(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowance BaggageAllowance
+		want      string
+	}{
+		{name: "structured kilograms", allowance: BaggageAllowance{Weight: intPtr(23), Unit: "kg"}, want: "23"},
+		{name: "structured pounds", allowance: BaggageAllowance{Weight: intPtr(50), Unit: "L"}, want: "23"},
+		{name: "structured weight without unit", allowance: BaggageAllowance{Weight: intPtr(20)}, want: "20"},
+		{
+			name:      "structured weight wins over the description",
+			allowance: BaggageAllowance{Weight: intPtr(32), Unit: "KG", Description: []string{"UP TO 23 KILOGRAMS"}},
+			want:      "32",
+		},
+		{name: "description in kilograms", allowance: BaggageAllowance{Description: []string{"CHECKED BAG", "UP TO 23 KILOGRAMS"}}, want: "23"},
+		{name: "description with abbreviated unit", allowance: BaggageAllowance{Description: []string{"UPTO 20KG"}}, want: "20"},
+		{name: "description in lower case", allowance: BaggageAllowance{Description: []string{"up to 15 kgs"}}, want: "15"},
+		{name: "description in pounds first", allowance: BaggageAllowance{Description: []string{"UP TO 50 POUNDS/23 KILOGRAMS"}}, want: "23"},
+		{name: "description in pounds only", allowance: BaggageAllowance{Description: []string{"UP TO 70 LBS"}}, want: "32"},
+		{name: "unit inside a word", allowance: BaggageAllowance{Description: []string{"10KGSX"}}, want: "-"},
+		{name: "pieces only", allowance: BaggageAllowance{Pieces: intPtr(2), Description: []string{"2 PIECES"}}, want: "-"},
+		{name: "nothing known", want: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatOptional(allowanceWeightKg(tt.allowance)); got != tt.want {
+				t.Errorf("allowanceWeightKg() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// baggageOffer returns a one-leg offer over two segments with the given passengers on each
+func baggageOffer(first, second []SegmentPassenger) *Offer {
+	return &Offer{
+		Price: Price{Total: 500, Currency: "USD"},
+		Legs: []Leg{{Segments: []Segment{
+			{Departure: Endpoint{Airport: "JFK"}, Arrival: Endpoint{Airport: "ORD"}, Passengers: first},
+			{Departure: Endpoint{Airport: "ORD"}, Arrival: Endpoint{Airport: "LAX"}, Passengers: second},
+		}}},
+	}
+}
+
+func TestComputeBaggagePolicies(t *testing.T) {
+	firstBag := BaggageCharge{Amount: 35, Currency: "USD", FirstPiece: 1, LastPiece: 1}
+	secondBag := BaggageCharge{Amount: 45, Currency: "USD", FirstPiece: 2, LastPiece: 2}
+
+	tests := []struct {
+		name  string
+		offer *Offer
+		want  []string // Type, count, checked pieces, weight, carry-on, first and second fee of each policy
+	}{
+		{
+			name: "piece allowance narrowed across segments",
+			offer: baggageOffer(
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 2, Baggage: []BaggageAllowance{{Pieces: intPtr(2), Weight: intPtr(23), Unit: "KG"}}}},
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 2, Baggage: []BaggageAllowance{{Pieces: intPtr(1), Weight: intPtr(32), Unit: "KG"}}}},
+			),
+			want: []string{"ADT 2 1 23 - - -"},
+		},
+		{
+			name: "weight allowance",
+			offer: baggageOffer(
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1, Baggage: []BaggageAllowance{{Description: []string{"UP TO 30 KILOGRAMS"}}}}},
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1, Baggage: []BaggageAllowance{{Weight: intPtr(20), Unit: "K"}}}},
+			),
+			want: []string{"ADT 1 - 20 - - -"},
+		},
+		{
+			name: "carry-on kept apart from checked bags",
+			offer: baggageOffer(
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1, Baggage: []BaggageAllowance{{Pieces: intPtr(0)}, {Pieces: intPtr(1), CarryOn: true}}}},
+				nil,
+			),
+			want: []string{"ADT 1 0 - 1 - -"},
+		},
+		{
+			name: "charges by piece and range, first segment wins",
+			offer: baggageOffer(
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1, BaggageCharges: []BaggageCharge{firstBag, secondBag}}},
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1, BaggageCharges: []BaggageCharge{{Amount: 60, Currency: "USD", FirstPiece: 1, LastPiece: 2}}}},
+			),
+			want: []string{"ADT 1 - - - 35 USD 45 USD"},
+		},
+		{
+			name: "charge covering both bags",
+			offer: baggageOffer(
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1, BaggageCharges: []BaggageCharge{{Amount: 60, Currency: "USD", FirstPiece: 1, LastPiece: 2}}}},
+				nil,
+			),
+			want: []string{"ADT 1 - - - 60 USD 60 USD"},
+		},
+		{
+			name: "carry-on charges ignored",
+			offer: baggageOffer(
+				[]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1, BaggageCharges: []BaggageCharge{{Amount: 25, Currency: "USD", FirstPiece: 1, CarryOn: true}}}},
+				nil,
+			),
+			want: []string{"ADT 1 - - - - -"},
+		},
+		{
+			name: "one policy per passenger type",
+			offer: baggageOffer(
+				[]SegmentPassenger{
+					{PassengerType: "ADT", PassengerNumber: 1, Baggage: []BaggageAllowance{{Pieces: intPtr(1)}}},
+					{PassengerType: "CNN", PassengerNumber: 2, Baggage: []BaggageAllowance{{Pieces: intPtr(1)}}},
+				},
+				[]SegmentPassenger{
+					{PassengerType: "ADT", PassengerNumber: 1, Baggage: []BaggageAllowance{{Pieces: intPtr(1)}}},
+					{PassengerType: "CNN", PassengerNumber: 2, Baggage: []BaggageAllowance{{Pieces: intPtr(0)}}},
+				},
+			),
+			want: []string{"ADT 1 1 - - - -", "CNN 2 0 - - - -"},
+		},
+		{
+			name:  "no baggage data",
+			offer: baggageOffer([]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1}}, nil),
+			want:  []string{"ADT 1 - - - - -"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.offer.ComputeBaggagePolicies()
+			if len(tt.offer.BaggagePolicies) != len(tt.want) {
+				t.Fatalf("got %d policies, want %d: %+v", len(tt.offer.BaggagePolicies), len(tt.want), tt.offer.BaggagePolicies)
+			}
+			for i, p := range tt.offer.BaggagePolicies {
+				got := fmt.Sprintf("%s %d %s %s %s %s %s", p.PassengerType, p.PassengerCount,
+					formatOptional(p.CheckedBags), formatOptional(p.CheckedWeightKg), formatOptional(p.CarryOnBags),
+					formatFee(p.FirstBagFee), formatFee(p.SecondBagFee))
+				if got != tt.want[i] {
+					t.Errorf("policy %d = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestComputeBaggagePoliciesPerLeg(t *testing.T) {
+	offer := baggageOffer([]SegmentPassenger{{PassengerType: "ADT", PassengerNumber: 1, Baggage: []BaggageAllowance{{Pieces: intPtr(1)}}}}, nil)
+	offer.Legs = append(offer.Legs, Leg{Segments: []Segment{{Passengers: []SegmentPassenger{
+		{PassengerType: "ADT", PassengerNumber: 1, Baggage: []BaggageAllowance{{Pieces: intPtr(0)}}},
+	}}}})
+
+	offer.ComputeBaggagePolicies()
+	if len(offer.BaggagePolicies) != 2 {
+		t.Fatalf("expected a policy per leg, got %+v", offer.BaggagePolicies)
+	}
+	for i, want := range []int{1, 0} {
+		p := offer.BaggagePolicies[i]
+		if p.Leg != i || p.CheckedBags == nil || *p.CheckedBags != want {
+			t.Errorf("leg %d policy = %+v, want %d checked bags", i, p, want)
+		}
+	}
+}
+
+func TestPriceWithCheckedBags(t *testing.T) {
+	usd := func(amount float64) *Money {
+		return &Money{Amount: amount, Currency: "USD"}
+	}
+	tests := []struct {
+		name         string
+		policies     []BaggagePolicy
+		bags         int
+		wantFees     float64
+		wantTotal    float64
+		wantComplete bool
+	}{
+		{
+			name:         "bags within the piece allowance",
+			policies:     []BaggagePolicy{{PassengerType: "ADT", PassengerCount: 2, CheckedBags: intPtr(1), FirstBagFee: usd(35)}},
+			bags:         1,
+			wantTotal:    500,
+			wantComplete: true,
+		},
+		{
+			name:         "extra bag for every passenger",
+			policies:     []BaggagePolicy{{PassengerType: "ADT", PassengerCount: 2, CheckedBags: intPtr(1), FirstBagFee: usd(35)}},
+			bags:         2,
+			wantFees:     70,
+			wantTotal:    570,
+			wantComplete: true,
+		},
+		{
+			name: "first and second bag on two legs",
+			policies: []BaggagePolicy{
+				{Leg: 0, PassengerType: "ADT", PassengerCount: 1, CheckedBags: intPtr(0), FirstBagFee: usd(35), SecondBagFee: usd(45.5)},
+				{Leg: 1, PassengerType: "ADT", PassengerCount: 1, CheckedBags: intPtr(0), FirstBagFee: usd(30), SecondBagFee: usd(40)},
+			},
+			bags:         2,
+			wantFees:     150.5,
+			wantTotal:    650.5,
+			wantComplete: true,
+		},
+		{
+			name:      "second bag fee unknown",
+			policies:  []BaggagePolicy{{PassengerType: "ADT", PassengerCount: 1, CheckedBags: intPtr(0), FirstBagFee: usd(35)}},
+			bags:      2,
+			wantFees:  35,
+			wantTotal: 535,
+		},
+		{
+			name:      "weight allowance has no piece count",
+			policies:  []BaggagePolicy{{PassengerType: "ADT", PassengerCount: 1, CheckedWeightKg: intPtr(30), FirstBagFee: usd(35)}},
+			bags:      1,
+			wantTotal: 500,
+		},
+		{
+			name:      "fee in another currency",
+			policies:  []BaggagePolicy{{PassengerType: "ADT", PassengerCount: 1, CheckedBags: intPtr(0), FirstBagFee: &Money{Amount: 30, Currency: "EUR"}}},
+			bags:      1,
+			wantTotal: 500,
+		},
+		{
+			name:      "no policies",
+			bags:      1,
+			wantTotal: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offer := &Offer{Price: Price{Total: 500, Currency: "USD"}, BaggagePolicies: tt.policies}
+			got := offer.PriceWithCheckedBags(tt.bags)
+			if got.BaggageFees != tt.wantFees || got.Total != tt.wantTotal || got.Complete != tt.wantComplete {
+				t.Errorf("PriceWithCheckedBags(%d) = fees %g, total %g, complete %v; want %g, %g, %v",
+					tt.bags, got.BaggageFees, got.Total, got.Complete, tt.wantFees, tt.wantTotal, tt.wantComplete)
+			}
+			if got.Currency != "USD" || got.CheckedBags != tt.bags {
+				t.Errorf("unexpected price: %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Traveler is a named passenger on a booking
+type Traveler struct {
+	Type        string          `json:"type"` // ADT, CNN, C06 or INF
+	GivenName   string          `json:"given_name"`
+	Surname     string          `json:"surname"`
+	DateOfBirth string          `json:"date_of_birth"` // Format: YYYY-MM-DD
+	Gender      string          `json:"gender"`        // M or F
+	Document    *TravelDocument `json:"document,omitempty"`
+}
+
+// TravelDocument is the identity document a traveler flies with
+type TravelDocument struct {
+	Type           string `json:"type"` // P for passport, I for national ID
+	Number         string `json:"number"`
+	IssuingCountry string `json:"issuing_country"` // ISO 3166 alpha-2
+	Nationality    string `json:"nationality"`     // ISO 3166 alpha-2
+	ExpiryDate     string `json:"expiry_date"`     // Format: YYYY-MM-DD
+}
+
+// Contact is how the airline and agency reach the customer
+type Contact struct {
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+// BookingRequest holds everything needed to create a reservation
+type BookingRequest struct {
+	Itinerary ItinerarySelection `json:"itinerary"`
+	Travelers []Traveler         `json:"travelers"`
+	Contact   Contact            `json:"contact"`
+}
+
+// Booking is a reservation held with a provider
+type Booking struct {
+	RecordLocator      string          `json:"record_locator"`
+	Source             string          `json:"source"`
+	Status             string          `json:"status"`
+	TicketingTimeLimit string          `json:"ticketing_time_limit,omitempty"`
+	Price              *Price          `json:"price,omitempty"`
+	ValidatingCarrier  string          `json:"validating_carrier,omitempty"` // From the stored price quote; tickets are issued on it
+	Travelers          []Traveler      `json:"travelers,omitempty"`
+	Segments           []BookedSegment `json:"segments,omitempty"`
+	Tickets            []Ticket        `json:"tickets,omitempty"`
+}
+
+// BookedSegment is a flight held in a reservation
+type BookedSegment struct {
+	ID string `json:"id"` // Provider reference used to cancel the segment
+	SelectedSegment
+	Status string `json:"status"` // Airline segment status code, e.g. HK
+}
+
+// Ticket is an electronic ticket issued for a booking
+type Ticket struct {
+	Number    string `json:"number"`
+	Traveler  string `json:"traveler,omitempty"` // Name of the ticketed traveler
+	IssueDate string `json:"issue_date"`         // Format: YYYY-MM-DD
+	Status    string `json:"status"`
+}
+
+// Ticket statuses
+const (
+	TicketIssued = "issued"
+	TicketVoided = "voided"
+)
+
+// IssuedTickets returns the tickets of the booking that are still valid
+func (b *Booking) IssuedTickets() []Ticket {
+	var tickets []Ticket
+	for _, t := range b.Tickets {
+		if t.Status == TicketIssued {
+			tickets = append(tickets, t)
+		}
+	}
+	return tickets
+}
+
+// UnticketedTravelers returns the positions, from 1, of the travelers without an issued ticket
+// Tickets are matched to travelers by name; tickets without a name are counted
+// for the first travelers left unmatched.
+func (b *Booking) UnticketedTravelers() []int {
+	ticketed := make([]bool, len(b.Travelers))
+	unnamed := 0
+	for _, t := range b.IssuedTickets() {
+		matched := false
+		for i, traveler := range b.Travelers {
+			name := strings.TrimSpace(traveler.GivenName + " " + traveler.Surname)
+			if !ticketed[i] && strings.EqualFold(strings.TrimSpace(t.Traveler), name) {
+				ticketed[i], matched = true, true
+				break
+			}
+		}
+		if !matched && t.Traveler == "" {
+			unnamed++
+		}
+	}
+
+	var positions []int
+	for i := range b.Travelers {
+		if ticketed[i] {
+			continue
+		}
+		if unnamed > 0 {
+			unnamed--
+			continue
+		}
+		positions = append(positions, i+1)
+	}
+	return positions
+}
+
+// TicketingRequest holds what is needed to issue the tickets of a booking
+type TicketingRequest struct {
+	RecordLocator     string        `json:"record_locator"`
+	ValidatingCarrier string        `json:"validating_carrier"`
+	Payment           FormOfPayment `json:"payment"`
+	Travelers         []int         `json:"travelers,omitempty"` // Positions of the travelers to ticket, from 1; empty tickets them all
+}
+
+// Forms of payment accepted for ticketing
+const (
+	PaymentTypeCash = "cash"
+	PaymentTypeCard = "card"
+)
+
+// FormOfPayment is how the tickets are paid for
+type FormOfPayment struct {
+	Type string       `json:"type"`
+	Card *PaymentCard `json:"card,omitempty"`
+}
+
+// PaymentCard is a credit card used to pay for tickets
+type PaymentCard struct {
+	Code       string `json:"code"` // Card vendor code, e.g. VI or CA
+	Number     string `json:"number"`
+	ExpiryDate string `json:"expiry_date"` // Format: YYYY-MM
+}
+
+// Booking statuses
+const (
+	BookingHeld      = "held"      // Seats are held and waiting to be ticketed
+	BookingTicketed  = "ticketed"  // Tickets have been issued
+	BookingCancelled = "cancelled" // No active segments are left
+)
+
+// AgeOn returns the traveler's age in completed years on the given date
+func (t *Traveler) AgeOn(date time.Time) (int, error) {
+	dob, err := time.Parse("2006-01-02", t.DateOfBirth)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date_of_birth %q, expected YYYY-MM-DD", t.DateOfBirth)
+	}
+	if dob.After(date) {
+		return 0, fmt.Errorf("date_of_birth %s is after the travel date", t.DateOfBirth)
+	}
+	age := date.Year() - dob.Year()
+	if date.Month() < dob.Month() || (date.Month() == dob.Month() && date.Day() < dob.Day()) {
+		age--
+	}
+	return age, nil
+}
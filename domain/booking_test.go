@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUnticketedTravelers(t *testing.T) {
+	travelers := []Traveler{
+		{GivenName: "Ada", Surname: "Lovelace"},
+		{GivenName: "Charles", Surname: "Babbage"},
+		{GivenName: "Ada", Surname: "Lovelace"},
+	}
+	tests := []struct {
+		name    string
+		tickets []Ticket
+		want    []int
+	}{
+		{name: "no tickets", want: []int{1, 2, 3}},
+		{
+			name:    "matched by name, ignoring case",
+			tickets: []Ticket{{Traveler: "CHARLES BABBAGE", Status: TicketIssued}},
+			want:    []int{1, 3},
+		},
+		{
+			name:    "a name shared by two travelers covers one",
+			tickets: []Ticket{{Traveler: "Ada Lovelace", Status: TicketIssued}},
+			want:    []int{2, 3},
+		},
+		{
+			name:    "voided tickets do not count",
+			tickets: []Ticket{{Traveler: "Charles Babbage", Status: TicketVoided}},
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "unnamed tickets cover the first travelers left",
+			tickets: []Ticket{{Traveler: "Ada Lovelace", Status: TicketIssued}, {Status: TicketIssued}},
+			want:    []int{3},
+		},
+		{
+			name: "everyone ticketed",
+			tickets: []Ticket{
+				{Traveler: "Ada Lovelace", Status: TicketIssued},
+				{Traveler: "Ada Lovelace", Status: TicketIssued},
+				{Traveler: "Charles Babbage", Status: TicketIssued},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := &Booking{Travelers: travelers, Tickets: tt.tickets}
+			if got := booking.UnticketedTravelers(); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("UnticketedTravelers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
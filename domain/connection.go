@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// Connection time thresholds, in minutes
+const (
+	ShortConnection              = 60  // Below this a connection is risky
+	ShortAirportChangeConnection = 180 // Below this a connection changing airports is risky
+	LongConnection               = 360 // Above this a connection is a long wait
+)
+
+// Connection is the stop between two consecutive flights of a leg
+type Connection struct {
+	ArrivalAirport    string `json:"arrival_airport"`   // Where the inbound flight lands
+	DepartureAirport  string `json:"departure_airport"` // Where the outbound flight leaves
+	ArrivalTerminal   string `json:"arrival_terminal,omitempty"`
+	DepartureTerminal string `json:"departure_terminal,omitempty"`
+	// Duration is the layover in minutes; it is 0 and the time flags are unset
+	// when the provider's times could not be placed in a time zone
+	Duration       int  `json:"duration"`
+	Short          bool `json:"short"`           // Risky: below the minimum comfortable connection time
+	Long           bool `json:"long"`            // Waiting more than LongConnection minutes
+	Overnight      bool `json:"overnight"`       // The layover spans local midnight
+	AirportChange  bool `json:"airport_change"`  // The traveler must transfer to another airport
+	TerminalChange bool `json:"terminal_change"` // Same airport, different terminal
+}
+
+// ComputeConnections fills the connections of the leg from its segments
+func (l *Leg) ComputeConnections() {
+	l.Connections = nil
+	for i := 1; i < len(l.Segments); i++ {
+		inbound, outbound := l.Segments[i-1].Arrival, l.Segments[i].Departure
+		conn := Connection{
+			ArrivalAirport:    inbound.Airport,
+			DepartureAirport:  outbound.Airport,
+			ArrivalTerminal:   inbound.Terminal,
+			DepartureTerminal: outbound.Terminal,
+			AirportChange:     inbound.Airport != outbound.Airport,
+		}
+		conn.TerminalChange = !conn.AirportChange && inbound.Terminal != "" && outbound.Terminal != "" &&
+			inbound.Terminal != outbound.Terminal
+
+		arrival, errArr := time.Parse(time.RFC3339, inbound.LocalDateTime)
+		departure, errDep := time.Parse(time.RFC3339, outbound.LocalDateTime)
+		if errArr == nil && errDep == nil {
+			conn.Duration = int(departure.Sub(arrival).Minutes())
+			minimum := ShortConnection
+			if conn.AirportChange {
+				minimum = ShortAirportChangeConnection
+			}
+			conn.Short = conn.Duration < minimum
+			conn.Long = conn.Duration > LongConnection
+			conn.Overnight = departure.Format("2006-01-02") != arrival.Format("2006-01-02")
+		}
+		l.Connections = append(l.Connections, conn)
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Conversion records how a price was converted into the requested currency
+type Conversion struct {
+	OriginalTotal    float64 `json:"original_total"`
+	OriginalCurrency string  `json:"original_currency"`
+	Rate             float64 `json:"rate"`                // Units of the new currency per unit of the original
+	RateDate         string  `json:"rate_date,omitempty"` // Date the rate was published
+}
+
+// RateTable holds exchange rates against a single base currency
+type RateTable struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`  // Format: YYYY-MM-DD
+	Rates map[string]float64 `json:"rates"` // Units of each currency per unit of Base
+}
+
+// Rate returns the number of units of to per unit of from
+func (t *RateTable) Rate(from, to string) (float64, error) {
+	fromRate, err := t.baseRate(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := t.baseRate(to)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+// Supports reports whether the table has a rate for the currency
+func (t *RateTable) Supports(currency string) bool {
+	_, err := t.baseRate(currency)
+	return err == nil
+}
+
+// baseRate returns the rate of a currency against the base currency
+func (t *RateTable) baseRate(currency string) (float64, error) {
+	if currency == t.Base {
+		return 1, nil
+	}
+	rate, ok := t.Rates[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+	return rate, nil
+}
+
+// ISO 4217 currencies whose minor unit is not 2 decimals
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// MinorUnit returns the number of decimals a currency is expressed in
+func MinorUnit(currency string) int {
+	if digits, ok := minorUnits[currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+// RoundAmount rounds an amount half away from zero to the currency's minor unit
+func RoundAmount(amount float64, currency string) float64 {
+	scale := math.Pow(10, float64(MinorUnit(currency)))
+	return math.Round(amount*scale) / scale
+}
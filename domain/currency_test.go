@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+func TestRoundAmount(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     float64
+	}{
+		{amount: 123.455, currency: "USD", want: 123.46},
+		{amount: 123.454, currency: "USD", want: 123.45},
+		{amount: -0.125, currency: "EUR", want: -0.13},
+		{amount: 15234.5, currency: "JPY", want: 15235},
+		{amount: 15234.49, currency: "JPY", want: 15234},
+		{amount: 98.7654, currency: "KWD", want: 98.765},
+		{amount: 98.7655, currency: "KWD", want: 98.766},
+		{amount: 1.23456, currency: "CLF", want: 1.2346},
+	}
+
+	for _, tt := range tests {
+		if got := RoundAmount(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("RoundAmount(%v, %s) = %v, want %v", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestMinorUnit(t *testing.T) {
+	for currency, want := range map[string]int{"USD": 2, "EUR": 2, "JPY": 0, "KRW": 0, "KWD": 3, "BHD": 3, "CLF": 4} {
+		if got := MinorUnit(currency); got != want {
+			t.Errorf("MinorUnit(%s) = %d, want %d", currency, got, want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "errors"
+
+// Errors shared by providers and use cases; wrap them to add detail
+var (
+	// ErrNotSupported is returned when no configured provider offers the operation
+	ErrNotSupported = errors.New("operation not supported by the configured providers")
+	// ErrNoProviders is returned when a search runs without any flight provider configured
+	ErrNoProviders = errors.New("no flight providers configured")
+	// ErrNoAvailability is returned when the selected flights can no longer be sold
+	ErrNoAvailability = errors.New("itinerary is no longer available")
+	// ErrBookingNotFound is returned when the provider has no reservation under the record locator
+	ErrBookingNotFound = errors.New("booking not found")
+	// ErrBookingState is returned when the booking's status does not allow the operation
+	ErrBookingState = errors.New("operation not allowed in the current booking status")
+	// ErrSeatMapUnavailable is returned when the airline publishes no seat map for the flight
+	ErrSeatMapUnavailable = errors.New("seat map not available for this flight")
+	// ErrUnsupportedCurrency is returned when no exchange rate is known for a currency
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	// ErrVoidWindowClosed is returned when tickets can no longer be voided
+	ErrVoidWindowClosed = errors.New("void window has closed")
+	// ErrUnknownLocation is returned when a searched code is not in the airport and city reference data,
+	// or a code searched as a city is not a city
+	ErrUnknownLocation = errors.New("unknown airport or city code")
+	// ErrOfferNotFound is returned when no snapshot is kept under an itinerary ID, or it expired
+	ErrOfferNotFound = errors.New("offer not found or expired")
+	// ErrOfferMismatch is returned when a request does not fit the offer it refers to
+	ErrOfferMismatch = errors.New("request does not match the offer")
+)
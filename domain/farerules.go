@@ -0,0 +1,75 @@
+package domain
+
+// FareRulesQuery identifies a fare component whose rules are requested
+// The fields mirror FareComponent so a component from the search results can be passed as is.
+type FareRulesQuery struct {
+	FareBasisCode    string `json:"fare_basis_code"`
+	GoverningCarrier string `json:"governing_carrier"`
+	BeginAirport     string `json:"begin_airport"`
+	EndAirport       string `json:"end_airport"`
+	DepartureDate    string `json:"departure_date"` // Format: YYYY-MM-DD
+	FareRule         string `json:"fare_rule,omitempty"`
+	FareTariff       string `json:"fare_tariff,omitempty"`
+	VendorCode       string `json:"vendor_code,omitempty"`
+}
+
+// FareRules are the conditions of one fare component
+type FareRules struct {
+	FareBasisCode    string           `json:"fare_basis_code"`
+	GoverningCarrier string           `json:"governing_carrier"`
+	BeginAirport     string           `json:"begin_airport"`
+	EndAirport       string           `json:"end_airport"`
+	Penalties        *PenaltyRules    `json:"penalties,omitempty"`
+	MinimumStay      *StayRule        `json:"minimum_stay,omitempty"`
+	MaximumStay      *StayRule        `json:"maximum_stay,omitempty"`
+	AdvancePurchase  *AdvancePurchase `json:"advance_purchase,omitempty"`
+	Categories       []RuleCategory   `json:"categories"` // Raw rule text, ready for display
+}
+
+// ATPCO rule categories we interpret
+const (
+	RuleCategoryAdvancePurchase = 5
+	RuleCategoryMinimumStay     = 6
+	RuleCategoryMaximumStay     = 7
+	RuleCategoryPenalties       = 16
+)
+
+// RuleCategory is the raw text of one rule category
+type RuleCategory struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Text   string `json:"text"`
+}
+
+// PenaltyRules describe what changing or cancelling the ticket costs
+type PenaltyRules struct {
+	Change *Penalty `json:"change,omitempty"`
+	Cancel *Penalty `json:"cancel,omitempty"`
+}
+
+// Penalty tells whether an action is permitted and what it costs
+type Penalty struct {
+	Allowed bool   `json:"allowed"`
+	Fee     *Money `json:"fee,omitempty"` // Nil when free or not permitted
+}
+
+// Money is an amount in a currency
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// StayRule is a minimum or maximum stay at the destination
+type StayRule struct {
+	Restricted    bool `json:"restricted"`
+	Days          int  `json:"days,omitempty"`
+	Months        int  `json:"months,omitempty"`
+	SaturdayNight bool `json:"saturday_night,omitempty"` // A Saturday night stay is required
+}
+
+// AdvancePurchase is how long before departure the fare must be booked and ticketed
+type AdvancePurchase struct {
+	Restricted      bool `json:"restricted"`
+	ReservationDays int  `json:"reservation_days,omitempty"`
+	TicketingDays   int  `json:"ticketing_days,omitempty"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+// Location types
+const (
+	LocationAirport = "airport"
+	LocationCity    = "city" // Metropolitan area served by one or more airports
+)
+
+// Location is an airport or city from the reference data
+type Location struct {
+	Code      string   `json:"code"` // IATA airport or city code
+	Type      string   `json:"type"`
+	Name      string   `json:"name"`
+	CityCode  string   `json:"city_code"` // IATA code of the city the location belongs to
+	City      string   `json:"city"`
+	Country   string   `json:"country"`   // ISO 3166 alpha-2
+	TimeZone  string   `json:"time_zone"` // IANA time zone, e.g. Europe/London
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Airports  []string `json:"airports,omitempty"` // Airports serving a city
+}
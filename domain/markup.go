@@ -0,0 +1,33 @@
+package domain
+
+// MarkupRuleSet is a versioned list of markup and discount rules
+type MarkupRuleSet struct {
+	Version int          `json:"version"`
+	Rules   []MarkupRule `json:"rules"`
+}
+
+// Markup rule types
+const (
+	MarkupTypeMarkup   = "markup"
+	MarkupTypeDiscount = "discount"
+)
+
+// MarkupRule adjusts the selling price of the offers it matches
+// Empty match lists match everything. Fixed amounts and caps are in Currency,
+// which they require; a rule with a currency only applies to offers priced in it.
+type MarkupRule struct {
+	ID           string   `json:"id"`
+	Priority     int      `json:"priority"` // Lower values are evaluated first; the first match wins
+	Channels     []string `json:"channels,omitempty"`
+	Carriers     []string `json:"carriers,omitempty"` // Validating carriers
+	Origins      []string `json:"origins,omitempty"`
+	Destinations []string `json:"destinations,omitempty"`
+	Cabins       []string `json:"cabins,omitempty"` // Normalized cabin codes: Y, S, C or F
+	Type         string   `json:"type"`             // markup or discount
+	Percentage   float64  `json:"percentage,omitempty"`
+	Fixed        float64  `json:"fixed,omitempty"`
+	PerPassenger bool     `json:"per_passenger,omitempty"` // Charge Fixed once per seated passenger; lap infants are free
+	Min          *float64 `json:"min,omitempty"`           // Smallest adjustment applied
+	Max          *float64 `json:"max,omitempty"`           // Largest adjustment applied
+	Currency     string   `json:"currency,omitempty"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "strings"
+
+// mealServices describes the IATA meal service codes
+var mealServices = map[rune]string{
+	'B': "Breakfast",
+	'C': "Complimentary alcoholic beverages",
+	'D': "Dinner",
+	'F': "Food for purchase",
+	'G': "Food and beverages for purchase",
+	'H': "Hot meal",
+	'K': "Continental breakfast",
+	'L': "Lunch",
+	'M': "Meal",
+	'N': "No meal service",
+	'O': "Cold meal",
+	'P': "Alcoholic beverages for purchase",
+	'R': "Refreshments",
+	'S': "Snack",
+	'V': "Refreshments for purchase",
+}
+
+// MealService describes a meal code such as "LS"; unknown letters are skipped
+func MealService(code string) string {
+	var services []string
+	for _, letter := range strings.ToUpper(code) {
+		if service, ok := mealServices[letter]; ok {
+			services = append(services, service)
+		}
+	}
+	return strings.Join(services, ", ")
+}
@@ -0,0 +1,322 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Offer is a priced itinerary, independent of the provider it came from
+type Offer struct {
+	ID                string `json:"id"`                     // Provider reference, unique within one result
+	ItineraryID       string `json:"itinerary_id,omitempty"` // Stable, content-derived ID; see ComputeItineraryID
+	Source            string `json:"source"`                 // Name of the provider that produced the offer
+	Legs              []Leg  `json:"legs"`
+	Price             Price  `json:"price"`
+	ValidatingCarrier string `json:"validating_carrier,omitempty"`
+	LastTicketDate    string `json:"last_ticket_date,omitempty"`
+	// FareFamilies lists every brand the itinerary is sold in, cheapest first.
+	// Price and Legs describe the cheapest one.
+	FareFamilies []FareFamily `json:"fare_families,omitempty"`
+	// PassengerFares itemizes the provider's fare for each passenger type
+	PassengerFares []PassengerFare `json:"passenger_fares,omitempty"`
+	// TotalDuration is the time spent travelling over all legs, in minutes,
+	// including connections but not the stay between legs
+	TotalDuration int `json:"total_duration"`
+	// BaggagePolicies normalizes the segment baggage data, one per leg and passenger type
+	BaggagePolicies []BaggagePolicy `json:"baggage_policies,omitempty"`
+	// PriceWithBags is the total with the checked bags asked for in the search, if any
+	PriceWithBags *BaggagePrice `json:"price_with_bags,omitempty"`
+	// CO2Kg is the estimated CO2 emitted per passenger over all segments; unset
+	// when a segment could not be estimated
+	CO2Kg *float64 `json:"co2_kg,omitempty"`
+}
+
+// SeatsAvailable returns the fewest seats left on any segment of the offer
+// Returns false when no segment reports its seats.
+func (o *Offer) SeatsAvailable() (int, bool) {
+	seats, known := 0, false
+	for _, leg := range o.Legs {
+		for _, seg := range leg.Segments {
+			if seg.SeatsAvailable == nil {
+				continue
+			}
+			if !known || *seg.SeatsAvailable < seats {
+				seats, known = *seg.SeatsAvailable, true
+			}
+		}
+	}
+	return seats, known
+}
+
+// FareFamily is one branded fare an itinerary can be bought in
+type FareFamily struct {
+	OfferID         string   `json:"offer_id"` // Provider reference of this brand's pricing
+	BrandCode       string   `json:"brand_code"`
+	BrandName       string   `json:"brand_name"`
+	Price           Price    `json:"price"`
+	PriceDifference float64  `json:"price_difference"` // Amount above the cheapest brand
+	CheckedBags     *int     `json:"checked_bags,omitempty"`
+	SeatSelection   string   `json:"seat_selection"`
+	Changeable      string   `json:"changeable"`
+	Refundable      string   `json:"refundable"`
+	BookingClasses  []string `json:"booking_classes"` // One per segment, in itinerary order
+}
+
+// PassengerFare is the fare of one passenger of a type, with its taxes itemized
+// Amounts are per passenger and exclude our markup.
+type PassengerFare struct {
+	PassengerType  string   `json:"passenger_type"`
+	PassengerCount int      `json:"passenger_count"` // Passengers of this type on the offer
+	Base           float64  `json:"base"`
+	Taxes          []Tax    `json:"taxes,omitempty"`
+	TotalTaxes     float64  `json:"total_taxes"`
+	Total          float64  `json:"total"`
+	Currency       string   `json:"currency"`
+	NonRefundable  bool     `json:"non_refundable"`
+	FareBasisCodes []string `json:"fare_basis_codes,omitempty"` // In itinerary order
+}
+
+// PassengerFaresMismatch reports whether the passenger fares do not add up to the offer's net total
+// Returns the sum of the passenger fares alongside; offers without a breakdown never mismatch.
+func (o *Offer) PassengerFaresMismatch() (float64, bool) {
+	if len(o.PassengerFares) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, fare := range o.PassengerFares {
+		if fare.Currency != o.Price.Currency {
+			return 0, true
+		}
+		sum += fare.Total * float64(fare.PassengerCount)
+	}
+	sum = RoundAmount(sum, o.Price.Currency)
+	net := o.Price.Total - o.Price.Markup
+	return sum, sum != RoundAmount(net, o.Price.Currency)
+}
+
+// Tax is a tax, fee or charge included in a fare
+type Tax struct {
+	Code        string  `json:"code"`
+	Description string  `json:"description,omitempty"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+}
+
+// Availability of a fare family feature
+const (
+	FeatureIncluded   = "included"
+	FeatureChargeable = "chargeable"
+	FeatureNotOffered = "not_offered"
+	FeatureUnknown    = "unknown"
+)
+
+// ItineraryKey identifies the flights of an offer, independent of price and provider
+// Two offers with the same key fly the same flights at the same times.
+func (o *Offer) ItineraryKey() string {
+	var b strings.Builder
+	for i, leg := range o.Legs {
+		if i > 0 {
+			b.WriteString("/")
+		}
+		b.WriteString(leg.DepartureDate)
+		for _, seg := range leg.Segments {
+			fmt.Fprintf(&b, "|%s%d:%s-%s@%s", seg.MarketingCarrier, seg.MarketingFlightNumber,
+				seg.Departure.Airport, seg.Arrival.Airport, clockTime(seg.Departure.Time))
+		}
+	}
+	return b.String()
+}
+
+// ComputeDurations fills the arrival day offset and connections of every leg and
+// the total duration
+// A leg without an elapsed time from the provider gets one from its UTC timestamps.
+func (o *Offer) ComputeDurations() {
+	o.TotalDuration = 0
+	for i := range o.Legs {
+		leg := &o.Legs[i]
+		if len(leg.Segments) == 0 {
+			continue
+		}
+		leg.ComputeConnections()
+		first, last := leg.Segments[0], leg.Segments[len(leg.Segments)-1]
+		leg.ArrivalDayOffset = last.Arrival.DayOffset
+		if leg.ElapsedTime == 0 {
+			departure, errDep := time.Parse(time.RFC3339, first.Departure.UTCDateTime)
+			arrival, errArr := time.Parse(time.RFC3339, last.Arrival.UTCDateTime)
+			if errDep == nil && errArr == nil {
+				leg.ElapsedTime = int(arrival.Sub(departure).Minutes())
+			}
+		}
+		o.TotalDuration += leg.ElapsedTime
+	}
+}
+
+// clockTime reduces a provider time such as 08:00:00-04:00 to 08:00
+func clockTime(t string) string {
+	if len(t) >= 5 {
+		return t[:5]
+	}
+	return t
+}
+
+// Price is an amount broken down into base fare and taxes
+// Total is the selling price: Base and Taxes make up the net fare, and Markup
+// (negative for a discount) is added on top of it.
+type Price struct {
+	Total      float64     `json:"total"`
+	Base       float64     `json:"base"`
+	Taxes      float64     `json:"taxes"`
+	Net        float64     `json:"net,omitempty"`    // Fare charged by the provider
+	Markup     float64     `json:"markup,omitempty"` // Our markup or discount
+	Currency   string      `json:"currency"`
+	Conversion *Conversion `json:"conversion,omitempty"` // Set when converted from the provider's currency
+}
+
+// Leg is one direction of the journey (outbound or return)
+type Leg struct {
+	DepartureDate string `json:"departure_date"` // Format: YYYY-MM-DD
+	Origin        string `json:"origin"`
+	Destination   string `json:"destination"`
+	ElapsedTime   int    `json:"elapsed_time"` // Minutes
+	// ArrivalDayOffset is the number of days the leg arrives after its departure date
+	ArrivalDayOffset int          `json:"arrival_day_offset"`
+	Segments         []Segment    `json:"segments"`
+	Connections      []Connection `json:"connections,omitempty"` // One between each pair of consecutive segments
+}
+
+// Segment is a single flight within a leg
+type Segment struct {
+	Departure             Endpoint `json:"departure"`
+	Arrival               Endpoint `json:"arrival"`
+	MarketingCarrier      string   `json:"marketing_carrier"`
+	MarketingFlightNumber int      `json:"marketing_flight_number"`
+	OperatingCarrier      string   `json:"operating_carrier"`
+	OperatingFlightNumber int      `json:"operating_flight_number"`
+	Equipment             string   `json:"equipment"`
+	// Names from the reference data; empty when a code is unknown
+	MarketingCarrierName string `json:"marketing_carrier_name,omitempty"`
+	OperatingCarrierName string `json:"operating_carrier_name,omitempty"`
+	CarrierLogoKey       string `json:"carrier_logo_key,omitempty"` // Logo of the marketing carrier
+	EquipmentName        string `json:"equipment_name,omitempty"`
+	EquipmentCategory    string `json:"equipment_category,omitempty"`
+	// CodeshareDisclosure reads "Operated by ..." when another airline flies the segment
+	CodeshareDisclosure string             `json:"codeshare_disclosure,omitempty"`
+	ElapsedTime         int                `json:"elapsed_time"` // Minutes
+	StopCount           int                `json:"stop_count"`
+	MilesFlown          int                `json:"miles_flown,omitempty"`
+	ETicketable         bool               `json:"e_ticketable"`
+	BookingClass        string             `json:"booking_class,omitempty"`
+	Cabin               string             `json:"cabin,omitempty"`           // Cabin code as the provider sent it; see CabinCode
+	MealCode            string             `json:"meal_code,omitempty"`       // IATA meal service codes, one letter each
+	Meal                string             `json:"meal,omitempty"`            // Meal service described from MealCode
+	SeatsAvailable      *int               `json:"seats_available,omitempty"` // Seats left in the booking class; unset when unknown
+	CO2Kg               *float64           `json:"co2_kg,omitempty"`          // Estimated per passenger in the segment's cabin
+	Passengers          []SegmentPassenger `json:"passengers"`
+}
+
+// Cabin codes the cabins of all providers are normalized to
+const (
+	CabinEconomy        = "Y"
+	CabinPremiumEconomy = "S"
+	CabinBusiness       = "C"
+	CabinFirst          = "F"
+)
+
+// cabinAliases maps the other cabin codes providers send to the normalized ones
+var cabinAliases = map[string]string{
+	"W": CabinPremiumEconomy,
+	"J": CabinBusiness, // Premium business
+	"P": CabinFirst,    // Premium first
+}
+
+// NormalizeCabin returns the normalized code of a provider cabin code
+// Unknown codes are returned unchanged.
+func NormalizeCabin(code string) string {
+	if normalized, ok := cabinAliases[code]; ok {
+		return normalized
+	}
+	return code
+}
+
+// CabinCode returns the normalized cabin the segment is sold in
+// Segments without a cabin of their own fall back to their first passenger's fare.
+func (s *Segment) CabinCode() string {
+	if s.Cabin != "" {
+		return NormalizeCabin(s.Cabin)
+	}
+	if len(s.Passengers) > 0 && s.Passengers[0].FareComponent != nil {
+		return NormalizeCabin(s.Passengers[0].FareComponent.CabinCode)
+	}
+	return ""
+}
+
+// Endpoint is the departure or arrival side of a segment
+type Endpoint struct {
+	Airport  string `json:"airport"`
+	City     string `json:"city,omitempty"`
+	Country  string `json:"country,omitempty"`
+	Terminal string `json:"terminal,omitempty"`
+	Time     string `json:"time"` // Local time as reported by the provider
+	// LocalDateTime and UTCDateTime are RFC 3339 timestamps; they are empty when
+	// the provider's time could not be placed in the airport's time zone
+	LocalDateTime string `json:"local_date_time,omitempty"`
+	UTCDateTime   string `json:"utc_date_time,omitempty"`
+	DayOffset     int    `json:"day_offset"` // Days after the leg's departure date
+}
+
+// SetDateTime records the absolute time of an endpoint
+// Args:
+//
+//	t - The departure or arrival time, in the airport's local offset
+//	legDate - Departure date of the leg, the reference for the day offset
+func (e *Endpoint) SetDateTime(t time.Time, legDate time.Time) {
+	e.LocalDateTime = t.Format(time.RFC3339)
+	e.UTCDateTime = t.UTC().Format(time.RFC3339)
+	local := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	reference := time.Date(legDate.Year(), legDate.Month(), legDate.Day(), 0, 0, 0, 0, time.UTC)
+	e.DayOffset = int(local.Sub(reference).Hours() / 24)
+}
+
+// SegmentPassenger holds what applies to one passenger type on one segment
+type SegmentPassenger struct {
+	PassengerType   string             `json:"passenger_type"`
+	PassengerNumber int                `json:"passenger_number"`
+	NonRefundable   bool               `json:"non_refundable"`
+	FareComponent   *FareComponent     `json:"fare_component,omitempty"`
+	Baggage         []BaggageAllowance `json:"baggage,omitempty"`
+	BaggageCharges  []BaggageCharge    `json:"baggage_charges,omitempty"`
+}
+
+// FareComponent describes the fare used to price part of the journey
+type FareComponent struct {
+	FareBasisCode    string  `json:"fare_basis_code"`
+	GoverningCarrier string  `json:"governing_carrier,omitempty"`
+	BeginAirport     string  `json:"begin_airport"`
+	EndAirport       string  `json:"end_airport"`
+	CabinCode        string  `json:"cabin_code,omitempty"`
+	Amount           float64 `json:"amount,omitempty"`
+	Currency         string  `json:"currency,omitempty"`
+	FareRule         string  `json:"fare_rule,omitempty"`
+	FareTariff       string  `json:"fare_tariff,omitempty"`
+	VendorCode       string  `json:"vendor_code,omitempty"`
+}
+
+// BaggageAllowance is a free baggage allowance, in pieces or weight
+type BaggageAllowance struct {
+	Pieces      *int     `json:"pieces,omitempty"`
+	Weight      *int     `json:"weight,omitempty"`
+	Unit        string   `json:"unit,omitempty"`
+	CarryOn     bool     `json:"carry_on,omitempty"` // Cabin baggage rather than checked
+	Description []string `json:"description,omitempty"`
+}
+
+// BaggageCharge is the fee for a range of bags
+type BaggageCharge struct {
+	Amount      float64  `json:"amount"`
+	Currency    string   `json:"currency"`
+	FirstPiece  int      `json:"first_piece"`
+	LastPiece   int      `json:"last_piece"`
+	CarryOn     bool     `json:"carry_on,omitempty"` // Cabin baggage rather than checked
+	Description []string `json:"description,omitempty"`
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SearchPreferences narrow down the itineraries a search returns
+// Providers translate what they can into their own request; the rest is applied
+// to the offers afterwards.
+type SearchPreferences struct {
+	MinConnection    int           `json:"min_connection,omitempty"` // Minutes; 0 for no limit
+	MaxConnection    int           `json:"max_connection,omitempty"` // Minutes; 0 for no limit
+	ExcludeOvernight bool          `json:"exclude_overnight,omitempty"`
+	ExcludeRedEye    bool          `json:"exclude_red_eye,omitempty"`
+	Windows          []TimeWindows `json:"windows,omitempty"`    // Indexed like the legs: outbound, then return
+	MaxCO2Kg         float64       `json:"max_co2_kg,omitempty"` // Per passenger; 0 for no limit
+}
+
+// TimeWindows restricts the local departure and arrival times of a leg
+// Times use the format HH:MM; an empty bound is open, and an after bound later
+// than the before bound wraps past midnight.
+type TimeWindows struct {
+	DepartureAfter  string `json:"departure_after,omitempty"`
+	DepartureBefore string `json:"departure_before,omitempty"`
+	ArrivalAfter    string `json:"arrival_after,omitempty"`
+	ArrivalBefore   string `json:"arrival_before,omitempty"`
+}
+
+// Red-eye flights depart late in the evening or at night and land early in the morning
+const (
+	redEyeDepartureFrom  = "20:00"
+	redEyeDepartureUntil = "03:00"
+	redEyeArrivalFrom    = "04:00"
+	redEyeArrivalUntil   = "10:00"
+)
+
+// Window returns the time windows of the leg at the given index, if any
+func (p *SearchPreferences) Window(leg int) *TimeWindows {
+	if p == nil || leg >= len(p.Windows) {
+		return nil
+	}
+	return &p.Windows[leg]
+}
+
+// Accepts reports whether an offer satisfies the preferences
+// Connections and flights whose times are unknown cannot be judged and are accepted.
+func (p *SearchPreferences) Accepts(offer *Offer) bool {
+	if p == nil {
+		return true
+	}
+	if p.MaxCO2Kg > 0 && offer.CO2Kg != nil && *offer.CO2Kg > p.MaxCO2Kg {
+		return false
+	}
+	for i, leg := range offer.Legs {
+		for _, conn := range leg.Connections {
+			if conn.Duration == 0 {
+				continue
+			}
+			if p.MinConnection > 0 && conn.Duration < p.MinConnection {
+				return false
+			}
+			if p.MaxConnection > 0 && conn.Duration > p.MaxConnection {
+				return false
+			}
+			if p.ExcludeOvernight && conn.Overnight {
+				return false
+			}
+		}
+		if p.ExcludeRedEye {
+			for _, seg := range leg.Segments {
+				if seg.IsRedEye() {
+					return false
+				}
+			}
+		}
+		if w := p.Window(i); w != nil && len(leg.Segments) > 0 {
+			first, last := leg.Segments[0], leg.Segments[len(leg.Segments)-1]
+			if !inWindow(first.Departure.LocalDateTime, w.DepartureAfter, w.DepartureBefore) ||
+				!inWindow(last.Arrival.LocalDateTime, w.ArrivalAfter, w.ArrivalBefore) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsRedEye reports whether the flight departs at night and lands early in the morning
+func (s *Segment) IsRedEye() bool {
+	departure, errDep := localClock(s.Departure.LocalDateTime)
+	arrival, errArr := localClock(s.Arrival.LocalDateTime)
+	if errDep != nil || errArr != nil {
+		return false
+	}
+	nightDeparture := departure >= redEyeDepartureFrom || departure < redEyeDepartureUntil
+	morningArrival := arrival >= redEyeArrivalFrom && arrival < redEyeArrivalUntil
+	return nightDeparture && morningArrival
+}
+
+// inWindow reports whether the local clock time of an RFC 3339 timestamp lies within [after, before]
+// A window whose after bound is later than its before bound spans midnight.
+func inWindow(timestamp, after, before string) bool {
+	clock, err := localClock(timestamp)
+	if err != nil {
+		return true
+	}
+	if after != "" && before != "" && after > before {
+		return clock >= after || clock <= before
+	}
+	return (after == "" || clock >= after) && (before == "" || clock <= before)
+}
+
+// localClock returns the HH:MM local time of an RFC 3339 timestamp
+func localClock(timestamp string) (string, error) {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return "", fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	return t.Format("15:04"), nil
+}
@@ -0,0 +1,119 @@
+package domain
+
+import "testing"
+
+func TestSearchPreferencesAccepts(t *testing.T) {
+	// preferredOffer flies JFK-ORD-LAX, connecting 95 minutes in Chicago
+	preferredOffer := func() *Offer {
+		co2 := 180.0
+		return &Offer{
+			CO2Kg: &co2,
+			Legs: []Leg{{
+				Segments: []Segment{
+					{
+						Departure: Endpoint{Airport: "JFK", LocalDateTime: "2025-06-01T06:00:00-04:00"},
+						Arrival:   Endpoint{Airport: "ORD", LocalDateTime: "2025-06-01T07:35:00-05:00"},
+					},
+					{
+						Departure: Endpoint{Airport: "ORD", LocalDateTime: "2025-06-01T09:10:00-05:00"},
+						Arrival:   Endpoint{Airport: "LAX", LocalDateTime: "2025-06-01T11:35:00-07:00"},
+					},
+				},
+				Connections: []Connection{{ArrivalAirport: "ORD", DepartureAirport: "ORD", Duration: 95}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		prefs  *SearchPreferences
+		mutate func(offer *Offer)
+		want   bool
+	}{
+		{name: "no preferences", want: true},
+		{name: "connection within limits", prefs: &SearchPreferences{MinConnection: 60, MaxConnection: 120}, want: true},
+		{name: "connection too short", prefs: &SearchPreferences{MinConnection: 120}},
+		{name: "connection too long", prefs: &SearchPreferences{MaxConnection: 90}},
+		{
+			name:  "connection of unknown length",
+			prefs: &SearchPreferences{MinConnection: 120},
+			mutate: func(offer *Offer) {
+				offer.Legs[0].Connections[0].Duration = 0
+			},
+			want: true,
+		},
+		{
+			name:  "overnight connection",
+			prefs: &SearchPreferences{ExcludeOvernight: true},
+			mutate: func(offer *Offer) {
+				offer.Legs[0].Connections[0].Overnight = true
+			},
+		},
+		{name: "day flights are not red-eye", prefs: &SearchPreferences{ExcludeRedEye: true}, want: true},
+		{
+			name:  "red-eye flight",
+			prefs: &SearchPreferences{ExcludeRedEye: true},
+			mutate: func(offer *Offer) {
+				offer.Legs[0].Segments[0].Departure.LocalDateTime = "2025-06-01T23:30:00-04:00"
+				offer.Legs[0].Segments[0].Arrival.LocalDateTime = "2025-06-02T05:10:00-05:00"
+			},
+		},
+		{
+			name:  "departure and arrival inside the windows",
+			prefs: &SearchPreferences{Windows: []TimeWindows{{DepartureAfter: "06:00", DepartureBefore: "09:00", ArrivalBefore: "12:00"}}},
+			want:  true,
+		},
+		{
+			name:  "departure before the window",
+			prefs: &SearchPreferences{Windows: []TimeWindows{{DepartureAfter: "07:00"}}},
+		},
+		{
+			name:  "arrival after the window",
+			prefs: &SearchPreferences{Windows: []TimeWindows{{ArrivalBefore: "11:00"}}},
+		},
+		{
+			name:  "departure inside a window across midnight",
+			prefs: &SearchPreferences{Windows: []TimeWindows{{DepartureAfter: "22:00", DepartureBefore: "06:30"}}},
+			want:  true,
+		},
+		{
+			name:  "departure outside a window across midnight",
+			prefs: &SearchPreferences{Windows: []TimeWindows{{DepartureAfter: "22:00", DepartureBefore: "05:00"}}},
+		},
+		{
+			name:  "window of a leg the offer does not fly",
+			prefs: &SearchPreferences{Windows: []TimeWindows{{}, {DepartureAfter: "18:00"}}},
+			want:  true,
+		},
+		{
+			name:  "times unknown to the window",
+			prefs: &SearchPreferences{Windows: []TimeWindows{{DepartureAfter: "07:00"}}},
+			mutate: func(offer *Offer) {
+				offer.Legs[0].Segments[0].Departure.LocalDateTime = ""
+			},
+			want: true,
+		},
+		{name: "emissions under the limit", prefs: &SearchPreferences{MaxCO2Kg: 200}, want: true},
+		{name: "emissions over the limit", prefs: &SearchPreferences{MaxCO2Kg: 150}},
+		{
+			name:  "emissions unknown",
+			prefs: &SearchPreferences{MaxCO2Kg: 150},
+			mutate: func(offer *Offer) {
+				offer.CO2Kg = nil
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offer := preferredOffer()
+			if tt.mutate != nil {
+				tt.mutate(offer)
+			}
+			if got := tt.prefs.Accepts(offer); got != tt.want {
+				t.Errorf("Accepts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
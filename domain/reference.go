@@ -0,0 +1,23 @@
+package domain
+
+// Airline is a carrier from the reference data
+type Airline struct {
+	Code    string `json:"code"` // IATA airline designator
+	Name    string `json:"name"`
+	LogoKey string `json:"logo_key"` // Key of the airline's logo in the asset store
+}
+
+// AircraftType is an aircraft model from the reference data
+type AircraftType struct {
+	Code     string `json:"code"` // IATA aircraft type code
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// Aircraft categories
+const (
+	AircraftNarrowbody = "narrowbody"
+	AircraftWidebody   = "widebody"
+	AircraftRegional   = "regional"
+	AircraftTurboprop  = "turboprop"
+)
@@ -0,0 +1,93 @@
+package domain
+
+// Trip types supported by the search
+const (
+	TripOneWay    = "one_way"
+	TripRoundTrip = "round_trip"
+)
+
+// Passenger type codes, using the IATA codes our API exposes
+const (
+	PassengerAdult  = "ADT"
+	PassengerChild  = "CNN"
+	PassengerChild6 = "C06"
+	PassengerInfant = "INF"
+)
+
+// PassengerCount is the number of travellers of a given type
+type PassengerCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// SearchQuery holds the provider-neutral flight search criteria
+// Origin and Destination are airport codes unless their type says city. When a
+// city or a list of airports was requested, the airports fields list every
+// airport the search accepts.
+type SearchQuery struct {
+	TripType            string             `json:"trip_type"`
+	Origin              string             `json:"origin"`
+	OriginType          string             `json:"origin_type,omitempty"` // LocationAirport or LocationCity
+	OriginAirports      []string           `json:"origin_airports,omitempty"`
+	Destination         string             `json:"destination"`
+	DestinationType     string             `json:"destination_type,omitempty"`
+	DestinationAirports []string           `json:"destination_airports,omitempty"`
+	DepartureDate       string             `json:"departure_date"` // Format: YYYY-MM-DD
+	ReturnDate          string             `json:"return_date,omitempty"`
+	Passengers          []PassengerCount   `json:"passengers"`
+	Preferences         *SearchPreferences `json:"preferences,omitempty"`
+}
+
+// SeatedPassengers returns the number of passengers needing a seat
+// Infants travel on an adult's lap.
+func (q *SearchQuery) SeatedPassengers() int {
+	return SeatedPassengers(q.Passengers)
+}
+
+// SeatedPassengers returns the number of passengers of a mix needing a seat
+func SeatedPassengers(passengers []PassengerCount) int {
+	seated := 0
+	for _, p := range passengers {
+		if p.Type != PassengerInfant {
+			seated += p.Count
+		}
+	}
+	return seated
+}
+
+// SearchResult is what a provider returns for a single search
+type SearchResult struct {
+	Offers   []Offer   `json:"offers"`
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// Warning codes reported alongside search results
+const (
+	WarningItinerarySkipped    = "ITINERARY_SKIPPED"     // The provider returned an itinerary we could not parse
+	WarningProviderFailed      = "PROVIDER_FAILED"       // A provider returned an error; its results are missing
+	WarningProviderTimeout     = "PROVIDER_TIMEOUT"      // A provider did not answer in time; its results are missing
+	WarningOfferNotConverted   = "OFFER_NOT_CONVERTED"   // An offer's currency could not be converted; it was dropped
+	WarningPriceMismatch       = "PRICE_MISMATCH"        // An offer's passenger fares do not add up to its total
+	WarningInsufficientSeats   = "INSUFFICIENT_SEATS"    // An offer has fewer seats left than passengers; it was dropped
+	WarningProviderMessage     = "PROVIDER_MESSAGE"      // A provider commented on the search; see the category
+	WarningSegmentTimesUnknown = "SEGMENT_TIMES_UNKNOWN" // A flight's time zone is unknown; only its local clock times are given
+)
+
+// Categories of provider messages reported as warnings
+const (
+	MessageNoAvailability = "no_availability" // Flights exist but none could be priced or sold
+	MessageNoSchedules    = "no_schedules"    // No flights operate for the criteria
+	MessageRequest        = "request"         // Part of the request was adjusted or ignored
+	MessageProcessing     = "processing"      // The provider failed part of the search
+	MessageInformation    = "information"     // Anything else the provider wanted to tell
+)
+
+// Warning describes a non-fatal problem encountered while building a result
+type Warning struct {
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	Source       string `json:"source,omitempty"`        // Provider that raised the warning
+	OfferID      string `json:"offer_id,omitempty"`      // Provider reference of the affected offer
+	Category     string `json:"category,omitempty"`      // Provider messages only
+	ProviderCode string `json:"provider_code,omitempty"` // Provider messages only: the provider's own code
+}
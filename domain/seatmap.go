@@ -0,0 +1,63 @@
+package domain
+
+// SeatMapQuery identifies the flight a seat map is requested for
+type SeatMapQuery struct {
+	MarketingCarrier string `json:"marketing_carrier"`
+	FlightNumber     int    `json:"flight_number"`
+	OperatingCarrier string `json:"operating_carrier,omitempty"`
+	DepartureDate    string `json:"departure_date"` // Format: YYYY-MM-DD
+	Origin           string `json:"origin"`
+	Destination      string `json:"destination"`
+	BookingClass     string `json:"booking_class"`
+}
+
+// SeatMap is the seat layout of one flight
+type SeatMap struct {
+	Source string  `json:"source"`
+	Flight string  `json:"flight"` // Carrier and flight number, e.g. LH400
+	Cabins []Cabin `json:"cabins"`
+}
+
+// Cabin is a compartment of the aircraft with its own seat layout
+type Cabin struct {
+	Code     string    `json:"code"`    // Cabin code: Y, W, C or F
+	Columns  []string  `json:"columns"` // Seat letters from left to right
+	FirstRow int       `json:"first_row"`
+	LastRow  int       `json:"last_row"`
+	Rows     []SeatRow `json:"rows"`
+}
+
+// SeatRow is a row of seats
+type SeatRow struct {
+	Number int    `json:"number"`
+	Seats  []Seat `json:"seats"`
+}
+
+// Seat is a single seat and whether it can be selected
+type Seat struct {
+	Number          string   `json:"number"` // Row and column, e.g. 12A
+	Column          string   `json:"column"`
+	Available       bool     `json:"available"`
+	Characteristics []string `json:"characteristics,omitempty"`
+	Price           *Price   `json:"price,omitempty"` // Nil when the seat is free of charge
+}
+
+// Normalized seat characteristics
+const (
+	SeatWindow       = "window"
+	SeatAisle        = "aisle"
+	SeatMiddle       = "middle"
+	SeatExitRow      = "exit_row"
+	SeatExtraLegroom = "extra_legroom"
+	SeatBulkhead     = "bulkhead"
+	SeatBassinet     = "bassinet"
+	SeatAccessible   = "accessible"
+	SeatNoInfant     = "infant_not_allowed"
+	SeatChargeable   = "chargeable"
+	SeatPreferred    = "preferred"
+	SeatRestricted   = "restricted_recline"
+	SeatOverWing     = "over_wing"
+	SeatNearLavatory = "near_lavatory"
+	SeatNearGalley   = "near_galley"
+	SeatNoChild      = "child_not_allowed"
+)
@@ -0,0 +1,66 @@
+package domain
+
+import "strings"
+
+// ItinerarySelection identifies the exact flights and booking classes a customer picked
+type ItinerarySelection struct {
+	Legs       []SelectedLeg    `json:"legs"`
+	Passengers []PassengerCount `json:"passengers"`
+}
+
+// SelectedLeg is one direction of the selected itinerary
+type SelectedLeg struct {
+	Segments []SelectedSegment `json:"segments"`
+}
+
+// SelectedSegment is a single flight of the selected itinerary
+type SelectedSegment struct {
+	MarketingCarrier  string `json:"marketing_carrier"`
+	FlightNumber      int    `json:"flight_number"`
+	OperatingCarrier  string `json:"operating_carrier,omitempty"`
+	Origin            string `json:"origin"`
+	Destination       string `json:"destination"`
+	DepartureDateTime string `json:"departure_date_time"` // Local time, format: YYYY-MM-DDTHH:MM:SS
+	ArrivalDateTime   string `json:"arrival_date_time"`   // Local time, format: YYYY-MM-DDTHH:MM:SS
+	BookingClass      string `json:"booking_class"`
+}
+
+// Matches reports whether an offer flies exactly the selected flights, on the
+// selected dates and in the selected booking classes
+func (s *ItinerarySelection) Matches(offer *Offer) bool {
+	if len(offer.Legs) != len(s.Legs) {
+		return false
+	}
+	for i, leg := range s.Legs {
+		if len(offer.Legs[i].Segments) != len(leg.Segments) {
+			return false
+		}
+		for j, sel := range leg.Segments {
+			seg := offer.Legs[i].Segments[j]
+			if seg.MarketingCarrier != sel.MarketingCarrier || seg.MarketingFlightNumber != sel.FlightNumber ||
+				seg.Departure.Airport != sel.Origin || seg.Arrival.Airport != sel.Destination {
+				return false
+			}
+			if !strings.EqualFold(seg.BookingClass, sel.BookingClass) {
+				return false
+			}
+			if !sameDeparture(localDateTime(seg.Departure, offer.Legs[i].DepartureDate), sel.DepartureDateTime) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sameDeparture reports whether a segment's local departure is the selected one
+// Times are compared to the minute; a selection without a time only fixes the date.
+func sameDeparture(departure, selected string) bool {
+	const minute = len("2006-01-02T15:04")
+	if len(selected) <= len("2006-01-02") {
+		return datePart(departure) == selected
+	}
+	if len(departure) < minute || len(selected) < minute {
+		return false
+	}
+	return departure[:minute] == selected[:minute]
+}
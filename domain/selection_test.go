@@ -0,0 +1,125 @@
+package domain
+
+import "testing"
+
+func TestItinerarySelectionMatches(t *testing.T) {
+	// selectedOffer flies JFK-ORD-LAX in V and O, connecting on the day it departs
+	selectedOffer := func() *Offer {
+		return &Offer{Legs: []Leg{{
+			DepartureDate: "2025-06-01",
+			Segments: []Segment{
+				{
+					Departure:        Endpoint{Airport: "JFK", Time: "06:00:00-04:00", LocalDateTime: "2025-06-01T06:00:00"},
+					Arrival:          Endpoint{Airport: "ORD", Time: "07:35:00-05:00"},
+					MarketingCarrier: "AA", MarketingFlightNumber: 1201, BookingClass: "V",
+				},
+				{
+					Departure:        Endpoint{Airport: "ORD", Time: "09:10:00-05:00"},
+					Arrival:          Endpoint{Airport: "LAX", Time: "11:35:00-07:00"},
+					MarketingCarrier: "AA", MarketingFlightNumber: 2203, BookingClass: "O",
+				},
+			},
+		}}}
+	}
+	selection := func() *ItinerarySelection {
+		return &ItinerarySelection{Legs: []SelectedLeg{{Segments: []SelectedSegment{
+			{MarketingCarrier: "AA", FlightNumber: 1201, Origin: "JFK", Destination: "ORD", DepartureDateTime: "2025-06-01T06:00:00", BookingClass: "V"},
+			{MarketingCarrier: "AA", FlightNumber: 2203, Origin: "ORD", Destination: "LAX", DepartureDateTime: "2025-06-01T09:10:00", BookingClass: "O"},
+		}}}}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(sel *ItinerarySelection, offer *Offer)
+		want   bool
+	}{
+		{name: "same flights", want: true},
+		{
+			name: "booking class in other case",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				sel.Legs[0].Segments[1].BookingClass = "o"
+			},
+			want: true,
+		},
+		{
+			name: "selection without seconds",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				sel.Legs[0].Segments[0].DepartureDateTime = "2025-06-01T06:00"
+			},
+			want: true,
+		},
+		{
+			name: "selection with date only",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				sel.Legs[0].Segments[1].DepartureDateTime = "2025-06-01"
+			},
+			want: true,
+		},
+		{
+			name: "different booking class",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				offer.Legs[0].Segments[1].BookingClass = "Q"
+			},
+		},
+		{
+			name: "booking class not reported",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				offer.Legs[0].Segments[0].BookingClass = ""
+			},
+		},
+		{
+			name: "different departure date",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				sel.Legs[0].Segments[0].DepartureDateTime = "2025-06-02T06:00:00"
+			},
+		},
+		{
+			name: "different departure date of a connection",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				offer.Legs[0].Segments[1].Departure.DayOffset = 1
+			},
+		},
+		{
+			name: "different departure time",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				sel.Legs[0].Segments[1].DepartureDateTime = "2025-06-01T19:10:00"
+			},
+		},
+		{
+			name: "different flight number",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				offer.Legs[0].Segments[0].MarketingFlightNumber = 1301
+			},
+		},
+		{
+			name: "different airport",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				offer.Legs[0].Segments[1].Arrival.Airport = "SNA"
+			},
+		},
+		{
+			name: "missing segment",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				offer.Legs[0].Segments = offer.Legs[0].Segments[:1]
+			},
+		},
+		{
+			name: "extra leg",
+			mutate: func(sel *ItinerarySelection, offer *Offer) {
+				offer.Legs = append(offer.Legs, offer.Legs[0])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, offer := selection(), selectedOffer()
+			if tt.mutate != nil {
+				tt.mutate(sel, offer)
+			}
+			if got := sel.Matches(offer); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
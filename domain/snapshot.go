@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ItineraryIDLength is the number of hexadecimal characters in an itinerary ID
+const ItineraryIDLength = 20
+
+// OfferSnapshot is an offer as a search returned it, kept so later requests can refer to it by ID
+type OfferSnapshot struct {
+	Offer      Offer            `json:"offer"`
+	Passengers []PassengerCount `json:"passengers"`        // Passenger mix the offer was priced for
+	Channel    string           `json:"channel,omitempty"` // Sales channel the offer was shown on
+	CreatedAt  time.Time        `json:"created_at"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+}
+
+// ComputeItineraryID derives the offer's ItineraryID from its content and the search it answered
+// The ID covers the flights, booking classes, fare bases and selling price, and the
+// source, passenger mix and channel the offer was priced for. The same itinerary sold
+// the same way gets the same ID in every search, while searches for other passengers
+// or channels get their own ID, so their snapshots never replace one another.
+func (o *Offer) ComputeItineraryID(passengers []PassengerCount, channel string) {
+	var b strings.Builder
+	b.WriteString(o.ItineraryKey())
+	for _, leg := range o.Legs {
+		for _, seg := range leg.Segments {
+			b.WriteString("|" + seg.BookingClass)
+			for _, passenger := range seg.Passengers {
+				b.WriteString(":" + passenger.PassengerType)
+				if passenger.FareComponent != nil {
+					b.WriteString("=" + passenger.FareComponent.FareBasisCode)
+				}
+			}
+		}
+	}
+	fmt.Fprintf(&b, "#%.2f %s@%s", o.Price.Total, o.Price.Currency, o.Source)
+	for _, p := range passengers {
+		fmt.Fprintf(&b, "/%d%s", p.Count, p.Type)
+	}
+	b.WriteString("~" + channel)
+	sum := sha256.Sum256([]byte(b.String()))
+	o.ItineraryID = hex.EncodeToString(sum[:])[:ItineraryIDLength]
+}
+
+// Selection returns the itinerary selection of the snapshot's offer
+func (s *OfferSnapshot) Selection() *ItinerarySelection {
+	sel := &ItinerarySelection{
+		Legs:       make([]SelectedLeg, 0, len(s.Offer.Legs)),
+		Passengers: s.Passengers,
+	}
+	for _, leg := range s.Offer.Legs {
+		segments := make([]SelectedSegment, 0, len(leg.Segments))
+		for _, seg := range leg.Segments {
+			segments = append(segments, SelectedSegment{
+				MarketingCarrier:  seg.MarketingCarrier,
+				FlightNumber:      seg.MarketingFlightNumber,
+				OperatingCarrier:  seg.OperatingCarrier,
+				Origin:            seg.Departure.Airport,
+				Destination:       seg.Arrival.Airport,
+				DepartureDateTime: localDateTime(seg.Departure, leg.DepartureDate),
+				ArrivalDateTime:   localDateTime(seg.Arrival, leg.DepartureDate),
+				BookingClass:      seg.BookingClass,
+			})
+		}
+		sel.Legs = append(sel.Legs, SelectedLeg{Segments: segments})
+	}
+	return sel
+}
+
+// FareRulesQueries returns one query per fare component of the snapshot's offer
+// The components are those of the first passenger type, in itinerary order; without
+// a governing carrier the component's first marketing carrier governs.
+func (s *OfferSnapshot) FareRulesQueries() []FareRulesQuery {
+	var queries []FareRulesQuery
+	seen := make(map[FareComponent]bool)
+	for _, leg := range s.Offer.Legs {
+		for _, seg := range leg.Segments {
+			if len(seg.Passengers) == 0 || seg.Passengers[0].FareComponent == nil {
+				continue
+			}
+			fc := *seg.Passengers[0].FareComponent
+			if seen[fc] {
+				continue
+			}
+			seen[fc] = true
+			if fc.GoverningCarrier == "" {
+				fc.GoverningCarrier = seg.MarketingCarrier
+			}
+			queries = append(queries, FareRulesQuery{
+				FareBasisCode:    fc.FareBasisCode,
+				GoverningCarrier: fc.GoverningCarrier,
+				BeginAirport:     fc.BeginAirport,
+				EndAirport:       fc.EndAirport,
+				DepartureDate:    datePart(localDateTime(seg.Departure, leg.DepartureDate)),
+				FareRule:         fc.FareRule,
+				FareTariff:       fc.FareTariff,
+				VendorCode:       fc.VendorCode,
+			})
+		}
+	}
+	return queries
+}
+
+// localDateTime returns an endpoint's local time in the format YYYY-MM-DDTHH:MM:SS
+// Endpoints that could not be placed in a time zone are dated from their leg.
+func localDateTime(e Endpoint, legDate string) string {
+	if len(e.LocalDateTime) >= len("2006-01-02T15:04:05") {
+		return e.LocalDateTime[:len("2006-01-02T15:04:05")]
+	}
+	date := legDate
+	if d, err := time.Parse("2006-01-02", legDate); err == nil {
+		date = d.AddDate(0, 0, e.DayOffset).Format("2006-01-02")
+	}
+	clock := e.Time
+	if len(clock) > len("15:04:05") {
+		clock = clock[:len("15:04:05")]
+	}
+	return date + "T" + clock
+}
+
+// datePart returns the YYYY-MM-DD part of a local date and time
+func datePart(dateTime string) string {
+	if len(dateTime) < len("2006-01-02") {
+		return dateTime
+	}
+	return dateTime[:len("2006-01-02")]
+}
@@ -3,10 +3,19 @@ package main
 import (
 	"log"
 
-	"github.com/Yordi-SE/FlightSearch/config"          // Package for loading configuration
-	"github.com/Yordi-SE/FlightSearch/delivery/router" // Package for setting up HTTP routes
-	"github.com/Yordi-SE/FlightSearch/use_case"        // Package containing business logic and Sabre client
-	"github.com/joho/godotenv"                         // Package for loading .env files
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces" // Package defining use case and provider interfaces
+	"github.com/Yordi-SE/FlightSearch/config"                // Package for loading configuration
+	"github.com/Yordi-SE/FlightSearch/delivery/router"       // Package for setting up HTTP routes
+	"github.com/Yordi-SE/FlightSearch/providers/aircraft"    // Aircraft reference data and emissions estimates
+	"github.com/Yordi-SE/FlightSearch/providers/airlines"    // Airline reference data
+	"github.com/Yordi-SE/FlightSearch/providers/amadeus"     // Amadeus flight offers adapter
+	"github.com/Yordi-SE/FlightSearch/providers/locations"   // Embedded airport and city reference data
+	"github.com/Yordi-SE/FlightSearch/providers/locks"       // Locks serializing ticketing operations
+	"github.com/Yordi-SE/FlightSearch/providers/rates"       // Exchange rates for display currencies
+	"github.com/Yordi-SE/FlightSearch/providers/sabre"       // Sabre Bargain Finder Max adapter
+	"github.com/Yordi-SE/FlightSearch/providers/snapshots"   // Offers kept for lookup by itinerary ID
+	"github.com/Yordi-SE/FlightSearch/use_case"              // Package containing business logic
+	"github.com/joho/godotenv"                               // Package for loading .env files
 )
 
 // main is the entry point of the application
@@ -26,19 +35,100 @@ func main() {
 		log.Fatal("Error loading config", err)
 	}
 
-	// Create a new Sabre client instance with configuration details
-	// The client will be used to interact with Sabre's API
-	FlightClient := use_case.NewSabreClient(Config)
+	// Airport and city codes are resolved, and flight times dated, against the embedded reference data
+	Locations, err := locations.NewDirectory()
+	if err != nil {
+		log.Fatal("Error loading locations", err)
+	}
+
+	// Create the content providers selected in the configuration
+	FlightProviders, err := newProviders(Config, Locations)
+	if err != nil {
+		log.Fatal("Error creating providers", err)
+	}
 
-	// Attempt to retrieve an authentication token from Sabre
-	// This is required before making any API calls
-	error := FlightClient.GetToken()
-	if error != nil {
-		// If token retrieval fails, log the error and terminate
-		log.Fatal("Error getting token", error)
+	// Post-search operations (revalidation, seat maps, fare rules, booking, ticketing) go through the first Sabre client;
+	// offers selected by ID are revalidated by the client that found them, and booked only if that is this one
+	var SabreClient *sabre.SabreClient
+	for _, provider := range FlightProviders {
+		if client, ok := provider.(*sabre.SabreClient); ok {
+			SabreClient = client
+			break
+		}
+	}
+	var Revalidator interfaces.Revalidator
+	var SeatMaps interfaces.SeatMapProvider
+	var FareRules interfaces.FareRulesProvider
+	var BookingProvider interfaces.BookingProvider
+	var TicketingProvider interfaces.TicketingProvider
+	if SabreClient != nil {
+		Revalidator = SabreClient
+		SeatMaps = SabreClient
+		FareRules = SabreClient
+		BookingProvider = SabreClient
+		TicketingProvider = SabreClient
+	}
+
+	// Display currencies are converted with the rates from the local rates file
+	Currency := use_case.NewCurrencyService(rates.NewFileSource(Config.RatesFile, Config.RatesReloadInterval))
+
+	// Selling prices come from the markup rules file
+	MarkupRules, err := config.LoadMarkupRules(Config.MarkupRulesFile)
+	if err != nil {
+		log.Fatal("Error loading markup rules", err)
 	}
+	Markup := use_case.NewMarkupService(MarkupRules)
 
-	// Initialize and start the HTTP router with the Sabre client
+	// Segments are named from the airline and aircraft reference data, which also
+	// holds the fuel-burn figures CO2 estimates are based on
+	Airlines, err := airlines.NewDirectory(Config.AirlinesFile)
+	if err != nil {
+		log.Fatal("Error loading airlines", err)
+	}
+	Aircraft, err := aircraft.NewDirectory(Config.AircraftFile)
+	if err != nil {
+		log.Fatal("Error loading aircraft", err)
+	}
+	Emissions := aircraft.NewEmissionsCalculator(Aircraft)
+
+	// Offers from a search stay available by ID for revalidation, booking and sharing
+	Offers := snapshots.NewMemoryStore(Config.OfferTTL)
+
+	// Initialize and start the HTTP router with the flight service
 	// This sets up the web server and API endpoints
-	router.NewRouter(FlightClient)
+	router.NewRouter(
+		use_case.NewFlightService(FlightProviders, Revalidator, SeatMaps, FareRules, Currency, Markup, Locations, Emissions, Airlines, Aircraft, Offers, Config.ProviderTimeout),
+		use_case.NewBookingService(BookingProvider, TicketingProvider, Offers, locks.NewMemoryStore()),
+		use_case.NewLocationService(Locations),
+	)
+}
+
+// newProviders builds and authenticates every configured flight provider
+// Sabre gets one client per configured PCC.
+func newProviders(Config *config.Config, airportTimes interfaces.AirportTimes) ([]interfaces.FlightProvider, error) {
+	var providers []interfaces.FlightProvider
+	for _, name := range Config.Providers {
+		switch name {
+		case config.ProviderAmadeus:
+			if Config.AmadeusFake {
+				providers = append(providers, amadeus.NewFakeAmadeusClient())
+				continue
+			}
+			client := amadeus.NewAmadeusClient(Config)
+			if err := client.GetToken(); err != nil {
+				return nil, err
+			}
+			providers = append(providers, client)
+		case config.ProviderSabre:
+			for _, pcc := range Config.PCCs {
+				// Retrieve a token up front; this is required before making any API calls
+				client := sabre.NewSabreClient(Config, pcc, airportTimes)
+				if err := client.GetToken(); err != nil {
+					return nil, err
+				}
+				providers = append(providers, client)
+			}
+		}
+	}
+	return providers, nil
 }
@@ -0,0 +1,103 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/config"
+	"github.com/Yordi-SE/FlightSearch/domain"
+	"github.com/Yordi-SE/FlightSearch/providers/amadeus"
+	"github.com/Yordi-SE/FlightSearch/providers/locations"
+	"github.com/Yordi-SE/FlightSearch/providers/sabre"
+)
+
+// newSabreProvider returns a Sabre client answering every search with a recorded response
+func newSabreProvider(t *testing.T, fixture string) interfaces.FlightProvider {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("sabre", "testdata", fixture))
+	if err != nil {
+		t.Fatalf("reading %s: %v", fixture, err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer test-token" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(raw)
+	}))
+	t.Cleanup(server.Close)
+
+	directory, err := locations.NewDirectory()
+	if err != nil {
+		t.Fatalf("loading locations: %v", err)
+	}
+	client := sabre.NewSabreClient(&config.Config{URL: server.URL, SabreBaseURL: server.URL}, "TEST", directory)
+	client.Token = "test-token"
+	return client
+}
+
+func TestFlightProviders(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider interfaces.FlightProvider
+		source   string
+	}{
+		{name: "sabre", provider: newSabreProvider(t, "one_way.json"), source: "sabre:TEST"},
+		{name: "amadeus", provider: amadeus.NewFakeAmadeusClient(), source: amadeus.ProviderName},
+	}
+
+	query := &domain.SearchQuery{
+		TripType:      domain.TripOneWay,
+		Origin:        "JFK",
+		Destination:   "LAX",
+		DepartureDate: "2025-06-01",
+		Passengers:    []domain.PassengerCount{{Type: domain.PassengerAdult, Count: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.provider.Name(); got != tt.source {
+				t.Fatalf("Name() = %q, want %q", got, tt.source)
+			}
+			result, err := tt.provider.SearchFlights(context.Background(), query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Offers) == 0 {
+				t.Fatal("expected offers")
+			}
+			for _, offer := range result.Offers {
+				if offer.Source != tt.source {
+					t.Errorf("offer source = %q, want %q", offer.Source, tt.source)
+				}
+				if offer.Price.Total <= 0 || offer.Price.Currency == "" {
+					t.Errorf("offer without price: %+v", offer.Price)
+				}
+				if len(offer.Legs) != 1 {
+					t.Fatalf("expected 1 leg, got %d", len(offer.Legs))
+				}
+				segs := offer.Legs[0].Segments
+				if len(segs) == 0 {
+					t.Fatal("leg without segments")
+				}
+				if segs[0].Departure.Airport != "JFK" || segs[len(segs)-1].Arrival.Airport != "LAX" {
+					t.Errorf("leg flies %s-%s, want JFK-LAX", segs[0].Departure.Airport, segs[len(segs)-1].Arrival.Airport)
+				}
+				if offer.Legs[0].DepartureDate != query.DepartureDate {
+					t.Errorf("leg departs %q, want 2025-06-01", offer.Legs[0].DepartureDate)
+				}
+			}
+			for _, w := range result.Warnings {
+				if w.Source != tt.source {
+					t.Errorf("warning source = %q, want %q", w.Source, tt.source)
+				}
+			}
+		})
+	}
+}
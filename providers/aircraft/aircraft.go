@@ -0,0 +1,117 @@
+package aircraft
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// aircraftCSV is the aircraft reference data compiled into the binary
+//
+//go:embed aircraft.csv
+var aircraftCSV string
+
+// Columns of aircraft.csv
+const (
+	colCode = iota
+	colName
+	colCategory
+	colSeats
+	colLTOFuel
+	colCruiseFuel
+	columnCount
+)
+
+// Aircraft is an aircraft type with the figures used to estimate its emissions
+type Aircraft struct {
+	Code            string  // IATA aircraft type code, as sent in equipment fields
+	Name            string  // Manufacturer and model
+	Category        string  // Narrowbody, widebody, regional or turboprop
+	Seats           int     // Typical seats in a two- or three-class layout
+	LTOFuelKg       float64 // Fuel burnt in the landing and take-off cycle
+	CruiseFuelPerKm float64 // Fuel burnt per kilometre of climb, cruise and descent
+}
+
+// Directory serves aircraft reference data
+type Directory struct {
+	aircraft map[string]Aircraft
+}
+
+// NewDirectory creates and initializes a new Directory
+// The embedded dataset is used unless a file with the same columns is given,
+// so the data can be updated without a new build.
+// Args:
+//
+//	path - CSV file replacing the embedded dataset, or empty
+//
+// Returns:
+//
+//	Pointer to a new Directory instance or an error if the dataset cannot be read
+func NewDirectory(path string) (*Directory, error) {
+	data := aircraftCSV
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read aircraft file: %v", err)
+		}
+		data = string(raw)
+	}
+	types, err := parseAircraft(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Directory{aircraft: types}, nil
+}
+
+// Aircraft returns the aircraft type with the given IATA code
+func (d *Directory) Aircraft(code string) (*domain.AircraftType, bool) {
+	a, ok := d.aircraft[strings.ToUpper(code)]
+	if !ok {
+		return nil, false
+	}
+	return &domain.AircraftType{Code: a.Code, Name: a.Name, Category: a.Category}, true
+}
+
+// parseAircraft reads the aircraft types from CSV reference data, keyed by code
+func parseAircraft(data string) (map[string]Aircraft, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aircraft: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("aircraft dataset is empty")
+	}
+
+	types := make(map[string]Aircraft, len(records)-1)
+	// The first record is the header
+	for i, record := range records[1:] {
+		if len(record) != columnCount {
+			return nil, fmt.Errorf("aircraft line %d: expected %d columns, got %d", i+2, columnCount, len(record))
+		}
+		a := Aircraft{Code: record[colCode], Name: record[colName], Category: record[colCategory]}
+		switch a.Category {
+		case domain.AircraftNarrowbody, domain.AircraftWidebody, domain.AircraftRegional, domain.AircraftTurboprop:
+		default:
+			return nil, fmt.Errorf("aircraft line %d: invalid category %q", i+2, a.Category)
+		}
+		if a.Seats, err = strconv.Atoi(record[colSeats]); err != nil || a.Seats <= 0 {
+			return nil, fmt.Errorf("aircraft line %d: invalid seats %q", i+2, record[colSeats])
+		}
+		if a.LTOFuelKg, err = strconv.ParseFloat(record[colLTOFuel], 64); err != nil {
+			return nil, fmt.Errorf("aircraft line %d: invalid LTO fuel %q", i+2, record[colLTOFuel])
+		}
+		if a.CruiseFuelPerKm, err = strconv.ParseFloat(record[colCruiseFuel], 64); err != nil {
+			return nil, fmt.Errorf("aircraft line %d: invalid cruise fuel %q", i+2, record[colCruiseFuel])
+		}
+		if _, ok := types[a.Code]; ok {
+			return nil, fmt.Errorf("aircraft line %d: duplicate aircraft %s", i+2, a.Code)
+		}
+		types[a.Code] = a
+	}
+	return types, nil
+}
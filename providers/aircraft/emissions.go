@@ -0,0 +1,171 @@
+package aircraft
+
+import (
+	"math"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+	"github.com/Yordi-SE/FlightSearch/providers/locations"
+)
+
+// Constants of the emissions methodology, a simplified form of the ICAO Carbon
+// Emissions Calculator: the fuel of a flight is its landing and take-off fuel
+// plus cruise fuel proportional to the distance, and the CO2 it produces is
+// shared among the occupied economy-equivalent seats, then weighted by cabin.
+const (
+	co2PerKgFuel      = 3.16 // Kilograms of CO2 per kilogram of jet fuel burnt
+	loadFactor        = 0.8  // Share of seats assumed occupied
+	routingCorrection = 95   // Kilometres added to great-circle distances for routing and holding
+	kmPerMile         = 1.609344
+	longHaulKm        = 3000 // Beyond this distance unknown aircraft are taken to be widebodies
+)
+
+// Distances of segments between airports missing from the reference data are
+// estimated from their scheduled time at a typical block speed
+const (
+	taxiMinutes           = 25 // Minutes of a schedule spent taxiing rather than flying
+	blockSpeedKmPerMinute = 13 // Kilometres flown per airborne minute, about 780 km/h
+)
+
+// Reference aircraft for equipment missing from the table
+// Without them in the dataset such segments are not estimated.
+const (
+	fallbackNarrowbody = "320"
+	fallbackWidebody   = "789"
+)
+
+// cabinFactors weights the seat share of each cabin by the floor space it takes
+// Cabins without a factor count as economy.
+var cabinFactors = map[string]float64{
+	domain.CabinEconomy:        1.0,
+	domain.CabinPremiumEconomy: 1.6,
+	domain.CabinBusiness:       2.9,
+	domain.CabinFirst:          4.0,
+}
+
+// cabinMix is the typical share of seats in each cabin by aircraft category
+// The reference data only gives an aircraft's total seats; the mix converts them
+// into economy-equivalent seats, so the CO2 of a full flight adds up to its fuel.
+var cabinMix = map[string]map[string]float64{
+	domain.AircraftNarrowbody: {domain.CabinEconomy: 0.90, domain.CabinBusiness: 0.10},
+	domain.AircraftRegional:   {domain.CabinEconomy: 0.92, domain.CabinBusiness: 0.08},
+	domain.AircraftTurboprop:  {domain.CabinEconomy: 1.0},
+	domain.AircraftWidebody: {
+		domain.CabinEconomy:        0.75,
+		domain.CabinPremiumEconomy: 0.08,
+		domain.CabinBusiness:       0.15,
+		domain.CabinFirst:          0.02,
+	},
+}
+
+// EmissionsCalculator estimates per-passenger CO2 from the aircraft reference data
+type EmissionsCalculator struct {
+	Directory *Directory // Fuel burn and seats of each aircraft type
+}
+
+// NewEmissionsCalculator creates and initializes a new EmissionsCalculator
+// Args:
+//
+//	directory - The aircraft reference data
+//
+// Returns:
+//
+//	Pointer to a new EmissionsCalculator instance
+func NewEmissionsCalculator(directory *Directory) *EmissionsCalculator {
+	return &EmissionsCalculator{
+		Directory: directory,
+	}
+}
+
+// Estimate sets the CO2 of every segment of an offer and of the offer as a whole
+// Segments whose distance cannot be told from the provider, the airports or the
+// schedule are left unset, and so is the offer then, as a partial total would
+// understate it.
+// Args:
+//
+//	offer - The offer to estimate, updated in place
+func (c *EmissionsCalculator) Estimate(offer *domain.Offer) {
+	var total float64
+	complete := true
+	for i := range offer.Legs {
+		for j := range offer.Legs[i].Segments {
+			seg := &offer.Legs[i].Segments[j]
+			co2, ok := c.SegmentCO2(seg)
+			if !ok {
+				seg.CO2Kg = nil
+				complete = false
+				continue
+			}
+			seg.CO2Kg = &co2
+			total += co2
+		}
+	}
+	offer.CO2Kg = nil
+	if complete {
+		total = math.Round(total*10) / 10
+		offer.CO2Kg = &total
+	}
+}
+
+// SegmentCO2 estimates the CO2 in kilograms of one passenger on a segment
+// The fuel of the flight is shared among its occupied economy-equivalent seats,
+// and the passenger's share is weighted by the cabin flown.
+func (c *EmissionsCalculator) SegmentCO2(seg *domain.Segment) (float64, bool) {
+	distance, ok := segmentDistance(seg)
+	if !ok {
+		return 0, false
+	}
+
+	types := c.Directory.aircraft
+	a, ok := types[seg.Equipment]
+	if !ok {
+		a = types[fallbackNarrowbody]
+		if distance > longHaulKm {
+			a = types[fallbackWidebody]
+		}
+	}
+	seats := economyEquivalentSeats(a)
+	if seats == 0 {
+		return 0, false
+	}
+
+	fuel := a.LTOFuelKg + a.CruiseFuelPerKm*distance
+	co2 := fuel * co2PerKgFuel / (seats * loadFactor) * cabinFactor(seg.CabinCode())
+	return math.Round(co2*10) / 10, true
+}
+
+// segmentDistance returns the distance in kilometres flown on a segment
+// The distance reported by the provider is preferred, then the great-circle
+// distance between the airports, then an estimate from the scheduled time.
+func segmentDistance(seg *domain.Segment) (float64, bool) {
+	if seg.MilesFlown > 0 {
+		return float64(seg.MilesFlown) * kmPerMile, true
+	}
+	if km, ok := locations.Distance(seg.Departure.Airport, seg.Arrival.Airport); ok {
+		return km + routingCorrection, true
+	}
+	if airborne := seg.ElapsedTime - taxiMinutes; airborne > 0 {
+		return float64(airborne * blockSpeedKmPerMinute), true
+	}
+	return 0, false
+}
+
+// economyEquivalentSeats converts an aircraft's seats into economy seats of the same floor space
+func economyEquivalentSeats(a Aircraft) float64 {
+	mix, ok := cabinMix[a.Category]
+	if !ok {
+		return float64(a.Seats)
+	}
+	var weight float64
+	for cabin, share := range mix {
+		weight += share * cabinFactor(cabin)
+	}
+	return float64(a.Seats) * weight
+}
+
+// cabinFactor returns the weight of a cabin, economy for unknown cabins
+func cabinFactor(cabin string) float64 {
+	if factor, ok := cabinFactors[cabin]; ok {
+		return factor
+	}
+	return cabinFactors[domain.CabinEconomy]
+}
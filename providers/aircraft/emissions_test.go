@@ -0,0 +1,167 @@
+package aircraft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// newTestCalculator returns a calculator over the embedded aircraft data
+func newTestCalculator(t *testing.T) *EmissionsCalculator {
+	t.Helper()
+	directory, err := NewDirectory("")
+	if err != nil {
+		t.Fatalf("loading aircraft: %v", err)
+	}
+	return NewEmissionsCalculator(directory)
+}
+
+func TestEconomyEquivalentSeats(t *testing.T) {
+	tests := []struct {
+		name     string
+		aircraft Aircraft
+		want     float64
+	}{
+		{name: "narrowbody", aircraft: Aircraft{Category: domain.AircraftNarrowbody, Seats: 165}, want: 165 * (0.90 + 0.10*2.9)},
+		{name: "widebody", aircraft: Aircraft{Category: domain.AircraftWidebody, Seats: 280}, want: 280 * (0.75 + 0.08*1.6 + 0.15*2.9 + 0.02*4.0)},
+		{name: "turboprop is all economy", aircraft: Aircraft{Category: domain.AircraftTurboprop, Seats: 70}, want: 70},
+		{name: "unknown category counts raw seats", aircraft: Aircraft{Category: "airship", Seats: 40}, want: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := economyEquivalentSeats(tt.aircraft); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("economyEquivalentSeats() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentCO2SharesTheWholeFlight(t *testing.T) {
+	// A full flight, at the assumed load factor, accounts for all the CO2 of its fuel
+	calculator := newTestCalculator(t)
+	for _, code := range []string{"320", "789", "E90", "AT7"} {
+		t.Run(code, func(t *testing.T) {
+			a := calculator.Directory.aircraft[code]
+			const miles = 1500
+			fuel := a.LTOFuelKg + a.CruiseFuelPerKm*miles*kmPerMile
+			want := fuel * co2PerKgFuel
+
+			var got float64
+			for cabin, share := range cabinMix[a.Category] {
+				co2, ok := calculator.SegmentCO2(&domain.Segment{Equipment: code, Cabin: cabin, MilesFlown: miles})
+				if !ok {
+					t.Fatalf("no estimate for cabin %s", cabin)
+				}
+				got += co2 * float64(a.Seats) * share * loadFactor
+			}
+			if math.Abs(got-want)/want > 0.001 {
+				t.Errorf("passengers emit %.0f kg in total, the flight burns fuel for %.0f kg", got, want)
+			}
+		})
+	}
+}
+
+func TestSegmentCO2(t *testing.T) {
+	calculator := newTestCalculator(t)
+	economy, ok := calculator.SegmentCO2(&domain.Segment{Equipment: "789", Cabin: "Y", MilesFlown: 3000})
+	if !ok {
+		t.Fatal("no economy estimate")
+	}
+
+	tests := []struct {
+		name    string
+		segment domain.Segment
+		want    float64 // Times the economy estimate on the 787-9
+		wantOK  bool
+	}{
+		{name: "business weighs its floor space", segment: domain.Segment{Equipment: "789", Cabin: "C", MilesFlown: 3000}, want: 2.9, wantOK: true},
+		{name: "premium economy by alias", segment: domain.Segment{Equipment: "789", Cabin: "W", MilesFlown: 3000}, want: 1.6, wantOK: true},
+		{name: "unknown cabin counts as economy", segment: domain.Segment{Equipment: "789", Cabin: "Z", MilesFlown: 3000}, want: 1, wantOK: true},
+		{name: "unknown long-haul aircraft as a widebody", segment: domain.Segment{Equipment: "XYZ", Cabin: "Y", MilesFlown: 3000}, want: 1, wantOK: true},
+		{name: "no distance", segment: domain.Segment{Equipment: "789", Cabin: "Y", Departure: domain.Endpoint{Airport: "XQZ"}, Arrival: domain.Endpoint{Airport: "XQY"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := calculator.SegmentCO2(&tt.segment)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && math.Abs(got-economy*tt.want) > 0.2 {
+				t.Errorf("SegmentCO2() = %.1f, want %.1f", got, economy*tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentDistance(t *testing.T) {
+	unknown := func(elapsed int) domain.Segment {
+		return domain.Segment{Departure: domain.Endpoint{Airport: "XQZ"}, Arrival: domain.Endpoint{Airport: "XQY"}, ElapsedTime: elapsed}
+	}
+	tests := []struct {
+		name    string
+		segment domain.Segment
+		want    float64
+		wantOK  bool
+	}{
+		{
+			name:    "distance flown from the provider",
+			segment: domain.Segment{Departure: domain.Endpoint{Airport: "JFK"}, Arrival: domain.Endpoint{Airport: "LAX"}, MilesFlown: 2475},
+			want:    2475 * kmPerMile,
+			wantOK:  true,
+		},
+		{
+			name:    "great circle between known airports",
+			segment: domain.Segment{Departure: domain.Endpoint{Airport: "JFK"}, Arrival: domain.Endpoint{Airport: "LAX"}},
+			want:    3974 + routingCorrection,
+			wantOK:  true,
+		},
+		{name: "scheduled time between unknown airports", segment: unknown(125), want: 100 * blockSpeedKmPerMinute, wantOK: true},
+		{name: "schedule shorter than taxiing", segment: unknown(20)},
+		{name: "nothing known", segment: unknown(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := segmentDistance(&tt.segment)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(got-tt.want) > 5 {
+				t.Errorf("segmentDistance() = %.0f km, want %.0f km", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	calculator := newTestCalculator(t)
+	known := domain.Segment{Equipment: "320", Cabin: "Y", MilesFlown: 700}
+	unknown := domain.Segment{Equipment: "320", Cabin: "Y", Departure: domain.Endpoint{Airport: "XQZ"}, Arrival: domain.Endpoint{Airport: "XQY"}}
+
+	t.Run("every segment estimated", func(t *testing.T) {
+		offer := &domain.Offer{Legs: []domain.Leg{{Segments: []domain.Segment{known, known}}, {Segments: []domain.Segment{known}}}}
+		calculator.Estimate(offer)
+		if offer.CO2Kg == nil {
+			t.Fatal("offer without estimate")
+		}
+		want := 3 * *offer.Legs[0].Segments[0].CO2Kg
+		if math.Abs(*offer.CO2Kg-want) > 0.05 {
+			t.Errorf("offer CO2 = %.1f, want %.1f", *offer.CO2Kg, want)
+		}
+	})
+
+	t.Run("a segment without distance leaves the offer unset", func(t *testing.T) {
+		co2 := 99.0
+		offer := &domain.Offer{CO2Kg: &co2, Legs: []domain.Leg{{Segments: []domain.Segment{known, unknown}}}}
+		calculator.Estimate(offer)
+		if offer.CO2Kg != nil {
+			t.Errorf("offer CO2 = %.1f, want unset", *offer.CO2Kg)
+		}
+		if offer.Legs[0].Segments[0].CO2Kg == nil || offer.Legs[0].Segments[1].CO2Kg != nil {
+			t.Error("only the segment with a distance should be estimated")
+		}
+	})
+}
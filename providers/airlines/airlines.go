@@ -0,0 +1,88 @@
+package airlines
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// airlinesCSV is the airline reference data compiled into the binary
+//
+//go:embed airlines.csv
+var airlinesCSV string
+
+// Columns of airlines.csv
+const (
+	colCode = iota
+	colName
+	colLogoKey
+	columnCount
+)
+
+// Directory serves airline reference data
+type Directory struct {
+	airlines map[string]domain.Airline
+}
+
+// NewDirectory creates and initializes a new Directory
+// The embedded dataset is used unless a file with the same columns is given,
+// so the data can be updated without a new build.
+// Args:
+//
+//	path - CSV file replacing the embedded dataset, or empty
+//
+// Returns:
+//
+//	Pointer to a new Directory instance or an error if the dataset cannot be read
+func NewDirectory(path string) (*Directory, error) {
+	data := airlinesCSV
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read airlines file: %v", err)
+		}
+		data = string(raw)
+	}
+	return parseDirectory(data)
+}
+
+// parseDirectory builds a Directory from CSV reference data
+func parseDirectory(data string) (*Directory, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read airlines: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("airlines dataset is empty")
+	}
+
+	d := &Directory{airlines: make(map[string]domain.Airline, len(records)-1)}
+	// The first record is the header
+	for i, record := range records[1:] {
+		if len(record) != columnCount {
+			return nil, fmt.Errorf("airlines line %d: expected %d columns, got %d", i+2, columnCount, len(record))
+		}
+		airline := domain.Airline{Code: record[colCode], Name: record[colName], LogoKey: record[colLogoKey]}
+		if len(airline.Code) != 2 || airline.Name == "" {
+			return nil, fmt.Errorf("airlines line %d: invalid code %q or name %q", i+2, airline.Code, airline.Name)
+		}
+		if _, ok := d.airlines[airline.Code]; ok {
+			return nil, fmt.Errorf("airlines line %d: duplicate airline %s", i+2, airline.Code)
+		}
+		d.airlines[airline.Code] = airline
+	}
+	return d, nil
+}
+
+// Airline returns the airline with the given IATA designator
+func (d *Directory) Airline(code string) (*domain.Airline, bool) {
+	airline, ok := d.airlines[strings.ToUpper(code)]
+	if !ok {
+		return nil, false
+	}
+	return &airline, true
+}
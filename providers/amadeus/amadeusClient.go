@@ -0,0 +1,166 @@
+package amadeus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Yordi-SE/FlightSearch/config"
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// ProviderName identifies Amadeus as the source of offers and warnings
+const ProviderName = "amadeus"
+
+// AmadeusClient represents a client for an Amadeus Self-Service style flight offers API
+type AmadeusClient struct {
+	ClientID     string       // API key used for authentication
+	ClientSecret string       // API secret used for authentication
+	Token        string       // Current access token (refreshed as needed)
+	URL          string       // Base URL of the API, e.g. https://test.api.amadeus.com
+	HTTPClient   *http.Client // HTTP client used for every call
+}
+
+// NewAmadeusClient creates and initializes a new AmadeusClient instance
+// Args:
+//
+//	Config - Application configuration holding the Amadeus credentials and URL
+//
+// Returns:
+//
+//	Pointer to a new AmadeusClient instance
+func NewAmadeusClient(Config *config.Config) *AmadeusClient {
+	return &AmadeusClient{
+		ClientID:     Config.AmadeusClientID,
+		ClientSecret: Config.AmadeusClientSecret,
+		URL:          strings.TrimSuffix(Config.AmadeusURL, "/"),
+		HTTPClient:   &http.Client{},
+	}
+}
+
+// NewFakeAmadeusClient returns a client wired to the in-process fake API
+// It lets the service run end to end without Amadeus credentials; its offers
+// are made up, so it is only used when AMADEUS_FAKE asks for it.
+func NewFakeAmadeusClient() *AmadeusClient {
+	return &AmadeusClient{
+		ClientID:     "fake",
+		ClientSecret: "fake",
+		URL:          FakeBaseURL,
+		HTTPClient:   &http.Client{Transport: NewFakeTransport()},
+	}
+}
+
+// Name returns the provider name reported on every offer
+func (c *AmadeusClient) Name() string {
+	return ProviderName
+}
+
+// GetToken retrieves an OAuth2 access token using the client credentials grant
+// Returns:
+//
+//	error - Any error encountered during the token retrieval process
+func (c *AmadeusClient) GetToken() error {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+
+	req, err := http.NewRequest("POST", c.URL+"/v1/security/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create token request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("token request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("token request returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return fmt.Errorf("failed to decode token response: %v", err)
+	}
+
+	c.Token = tokenResp.AccessToken
+	return nil
+}
+
+// SearchFlights executes a flight search using the flight offers search API
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The provider-neutral search criteria
+//
+// Returns:
+//
+//	Pointer to SearchResult with search results or an error if the request fails
+func (c *AmadeusClient) SearchFlights(ctx context.Context, req *domain.SearchQuery) (*domain.SearchResult, error) {
+	if c.Token == "" {
+		if err := c.GetToken(); err != nil {
+			return nil, fmt.Errorf("failed to obtain authentication token: %v", err)
+		}
+	}
+
+	payload, err := json.Marshal(BuildAmadeusRequest(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.URL+"/v2/shopping/flight-offers", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flight request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.Token)
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("flight request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("flight request returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var offersResp FlightOffersSearchResponse
+	if err := json.Unmarshal(body, &offersResp); err != nil {
+		return nil, fmt.Errorf("invalid response format from Amadeus API: %v", err)
+	}
+	if len(offersResp.Errors) > 0 {
+		e := offersResp.Errors[0]
+		return nil, fmt.Errorf("amadeus processing error: %s (%d)", e.Detail, e.Code)
+	}
+	if len(offersResp.Data) == 0 {
+		return nil, fmt.Errorf("no flights available for your search; try adjusting your dates or preferences")
+	}
+
+	result, err := ParseAmadeusResponse(offersResp, req)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Offers {
+		result.Offers[i].Source = c.Name()
+	}
+	for i := range result.Warnings {
+		result.Warnings[i].Source = c.Name()
+	}
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package amadeus
+
+// FlightOffersSearchRequest is the body of POST /v2/shopping/flight-offers
+type FlightOffersSearchRequest struct {
+	CurrencyCode       string              `json:"currencyCode,omitempty"`
+	OriginDestinations []OriginDestination `json:"originDestinations"`
+	Travelers          []Traveler          `json:"travelers"`
+	Sources            []string            `json:"sources"`
+	SearchCriteria     SearchCriteria      `json:"searchCriteria"`
+}
+
+// OriginDestination is one requested journey direction
+type OriginDestination struct {
+	ID                      string        `json:"id"`
+	OriginLocationCode      string        `json:"originLocationCode"`
+	DestinationLocationCode string        `json:"destinationLocationCode"`
+	AlternativeOrigins      []string      `json:"alternativeOriginsCodes,omitempty"`      // Other airports accepted as origin
+	AlternativeDestinations []string      `json:"alternativeDestinationsCodes,omitempty"` // Other airports accepted as destination
+	DepartureDateTimeRange  DateTimeRange `json:"departureDateTimeRange"`
+}
+
+// DateTimeRange restricts the departure date and optionally the time
+type DateTimeRange struct {
+	Date string `json:"date"`
+	Time string `json:"time,omitempty"`
+}
+
+// Traveler is a single passenger in the request
+type Traveler struct {
+	ID           string `json:"id"`
+	TravelerType string `json:"travelerType"`
+	AssociatedID string `json:"associatedAdultId,omitempty"` // Required for held infants
+}
+
+// SearchCriteria limits the search
+type SearchCriteria struct {
+	MaxFlightOffers int `json:"maxFlightOffers"`
+}
+
+// FlightOffersSearchResponse is the response of the flight offers search
+type FlightOffersSearchResponse struct {
+	Meta         Meta          `json:"meta"`
+	Data         []FlightOffer `json:"data"`
+	Dictionaries Dictionaries  `json:"dictionaries"`
+	Errors       []APIError    `json:"errors,omitempty"`
+}
+
+// Meta carries result metadata
+type Meta struct {
+	Count int `json:"count"`
+}
+
+// FlightOffer is a single priced offer
+type FlightOffer struct {
+	Type                   string            `json:"type"`
+	ID                     string            `json:"id"`
+	Source                 string            `json:"source"`
+	LastTicketingDate      string            `json:"lastTicketingDate"`
+	NumberOfBookableSeats  int               `json:"numberOfBookableSeats"`
+	Itineraries            []OfferItinerary  `json:"itineraries"`
+	Price                  OfferPrice        `json:"price"`
+	ValidatingAirlineCodes []string          `json:"validatingAirlineCodes"`
+	TravelerPricings       []TravelerPricing `json:"travelerPricings"`
+}
+
+// OfferItinerary is one direction of the offer
+type OfferItinerary struct {
+	Duration string         `json:"duration"` // ISO 8601 duration, e.g. PT6H20M
+	Segments []OfferSegment `json:"segments"`
+}
+
+// OfferSegment is a single flight
+type OfferSegment struct {
+	ID            string        `json:"id"`
+	Departure     FlightPoint   `json:"departure"`
+	Arrival       FlightPoint   `json:"arrival"`
+	CarrierCode   string        `json:"carrierCode"`
+	Number        string        `json:"number"`
+	Aircraft      Aircraft      `json:"aircraft"`
+	Operating     *OperatingRef `json:"operating,omitempty"`
+	Duration      string        `json:"duration"`
+	NumberOfStops int           `json:"numberOfStops"`
+}
+
+// FlightPoint is a departure or arrival
+type FlightPoint struct {
+	IataCode string `json:"iataCode"`
+	Terminal string `json:"terminal,omitempty"`
+	At       string `json:"at"` // Local date and time, e.g. 2025-06-01T08:00:00
+}
+
+// Aircraft identifies the equipment
+type Aircraft struct {
+	Code string `json:"code"`
+}
+
+// OperatingRef identifies the operating carrier of a codeshare
+type OperatingRef struct {
+	CarrierCode string `json:"carrierCode"`
+	Number      string `json:"number,omitempty"`
+}
+
+// OfferPrice is the total price of an offer or traveler
+type OfferPrice struct {
+	Currency   string `json:"currency"`
+	Total      string `json:"total"`
+	Base       string `json:"base"`
+	GrandTotal string `json:"grandTotal,omitempty"`
+}
+
+// TravelerPricing is the price and fare details of one traveler
+type TravelerPricing struct {
+	TravelerID           string                `json:"travelerId"`
+	FareOption           string                `json:"fareOption"`
+	TravelerType         string                `json:"travelerType"`
+	Price                OfferPrice            `json:"price"`
+	FareDetailsBySegment []FareDetailBySegment `json:"fareDetailsBySegment"`
+}
+
+// FareDetailBySegment holds the fare applied to one segment
+type FareDetailBySegment struct {
+	SegmentID           string       `json:"segmentId"`
+	Cabin               string       `json:"cabin"`
+	FareBasis           string       `json:"fareBasis"`
+	BrandedFare         string       `json:"brandedFare,omitempty"`
+	Class               string       `json:"class"`
+	IncludedCheckedBags *CheckedBags `json:"includedCheckedBags,omitempty"`
+}
+
+// CheckedBags is the included checked baggage allowance
+type CheckedBags struct {
+	Quantity   *int   `json:"quantity,omitempty"`
+	Weight     *int   `json:"weight,omitempty"`
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// Dictionaries resolves codes used in the offers
+type Dictionaries struct {
+	Carriers map[string]string `json:"carriers"`
+	Aircraft map[string]string `json:"aircraft"`
+}
+
+// APIError is an error entry returned by the API
+type APIError struct {
+	Status int    `json:"status"`
+	Code   int    `json:"code"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
@@ -0,0 +1,199 @@
+package amadeus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/providers/locations"
+)
+
+// FakeBaseURL is the base URL served by the fake transport
+const FakeBaseURL = "http://amadeus.fake"
+
+// fakeSchedule is a canned flight the fake API offers on every route
+type fakeSchedule struct {
+	carrier   string
+	number    int
+	aircraft  string
+	departure int // Hour of departure, local time
+	duration  int // Minutes
+	baseFare  float64
+	bags      int
+}
+
+var fakeSchedules = []fakeSchedule{
+	{carrier: "LH", number: 400, aircraft: "359", departure: 7, duration: 415, baseFare: 310, bags: 1},
+	{carrier: "AF", number: 23, aircraft: "77W", departure: 13, duration: 440, baseFare: 265, bags: 1},
+	{carrier: "KL", number: 644, aircraft: "789", departure: 19, duration: 425, baseFare: 240, bags: 0},
+}
+
+// Share of the adult fare charged per traveler type
+var fakeFareShare = map[string]float64{
+	"ADULT":       1,
+	"CHILD":       0.75,
+	"HELD_INFANT": 0.1,
+}
+
+// FakeTransport is an http.RoundTripper that answers like the flight offers API
+// It generates deterministic offers for any route so the adapter can be exercised
+// without network access or credentials.
+type FakeTransport struct{}
+
+// NewFakeTransport returns a new FakeTransport
+func NewFakeTransport() *FakeTransport {
+	return &FakeTransport{}
+}
+
+// RoundTrip serves the token and flight offers endpoints
+func (t *FakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+	switch req.URL.Path {
+	case "/v1/security/oauth2/token":
+		return fakeJSON(req, http.StatusOK, map[string]interface{}{
+			"type":         "amadeusOAuth2Token",
+			"access_token": "fake-access-token",
+			"expires_in":   1799,
+		})
+	case "/v2/shopping/flight-offers":
+		var search FlightOffersSearchRequest
+		if err := json.NewDecoder(req.Body).Decode(&search); err != nil {
+			return fakeJSON(req, http.StatusBadRequest, FlightOffersSearchResponse{
+				Errors: []APIError{{Status: 400, Code: 477, Title: "INVALID FORMAT", Detail: err.Error()}},
+			})
+		}
+		return fakeJSON(req, http.StatusOK, fakeOffers(search))
+	default:
+		return fakeJSON(req, http.StatusNotFound, FlightOffersSearchResponse{
+			Errors: []APIError{{Status: 404, Code: 38196, Title: "Resource not found"}},
+		})
+	}
+}
+
+// fakeOffers builds one offer per canned schedule for the requested journey
+func fakeOffers(search FlightOffersSearchRequest) FlightOffersSearchResponse {
+	resp := FlightOffersSearchResponse{
+		Dictionaries: Dictionaries{
+			Carriers: map[string]string{"LH": "LUFTHANSA", "AF": "AIR FRANCE", "KL": "KLM"},
+			Aircraft: map[string]string{"359": "AIRBUS A350-900", "77W": "BOEING 777-300ER", "789": "BOEING 787-9"},
+		},
+	}
+
+	for i, sched := range fakeSchedules {
+		offer := FlightOffer{
+			Type:                   "flight-offer",
+			ID:                     fmt.Sprint(i + 1),
+			Source:                 "GDS",
+			NumberOfBookableSeats:  9,
+			ValidatingAirlineCodes: []string{sched.carrier},
+		}
+
+		segmentID := 0
+		for j, od := range search.OriginDestinations {
+			date, err := time.Parse("2006-01-02", od.DepartureDateTimeRange.Date)
+			if err != nil {
+				continue
+			}
+			originCode := fakeAirport(od.OriginLocationCode, od.AlternativeOrigins, i)
+			destinationCode := fakeAirport(od.DestinationLocationCode, od.AlternativeDestinations, i)
+			departure := date.Add(time.Duration(sched.departure) * time.Hour)
+			arrival := departure.Add(time.Duration(sched.duration) * time.Minute)
+			// Real flights cross time zones: depart in the origin's local time, arrive in the destination's
+			if origin, ok := locations.TimeZone(originCode); ok {
+				departure = time.Date(departure.Year(), departure.Month(), departure.Day(), departure.Hour(), 0, 0, 0, origin)
+				arrival = departure.Add(time.Duration(sched.duration) * time.Minute)
+				if destination, ok := locations.TimeZone(destinationCode); ok {
+					arrival = arrival.In(destination)
+				}
+			}
+			segmentID++
+			offer.Itineraries = append(offer.Itineraries, OfferItinerary{
+				Duration: fakeDuration(sched.duration),
+				Segments: []OfferSegment{{
+					ID:          fmt.Sprint(segmentID),
+					Departure:   FlightPoint{IataCode: originCode, At: departure.Format("2006-01-02T15:04:05")},
+					Arrival:     FlightPoint{IataCode: destinationCode, At: arrival.Format("2006-01-02T15:04:05")},
+					CarrierCode: sched.carrier,
+					Number:      fmt.Sprint(sched.number + j),
+					Aircraft:    Aircraft{Code: sched.aircraft},
+					Duration:    fakeDuration(sched.duration),
+				}},
+			})
+			if j == 0 {
+				offer.LastTicketingDate = date.AddDate(0, 0, -3).Format("2006-01-02")
+			}
+		}
+		if len(offer.Itineraries) == 0 {
+			continue
+		}
+
+		var total, base float64
+		for _, traveler := range search.Travelers {
+			// Round per traveler like a real fare so the offer total is the sum of its travelers
+			travelerBase := math.Round(sched.baseFare*fakeFareShare[traveler.TravelerType]*float64(len(offer.Itineraries))*100) / 100
+			travelerTotal := math.Round(travelerBase*1.15*100) / 100
+			base += travelerBase
+			total += travelerTotal
+
+			pricing := TravelerPricing{
+				TravelerID:   traveler.ID,
+				FareOption:   "STANDARD",
+				TravelerType: traveler.TravelerType,
+				Price:        OfferPrice{Currency: "EUR", Total: fmt.Sprintf("%.2f", travelerTotal), Base: fmt.Sprintf("%.2f", travelerBase)},
+			}
+			for _, itin := range offer.Itineraries {
+				bags := sched.bags
+				pricing.FareDetailsBySegment = append(pricing.FareDetailsBySegment, FareDetailBySegment{
+					SegmentID:           itin.Segments[0].ID,
+					Cabin:               "ECONOMY",
+					FareBasis:           "K" + sched.carrier + "SAVER",
+					Class:               "K",
+					IncludedCheckedBags: &CheckedBags{Quantity: &bags},
+				})
+			}
+			offer.TravelerPricings = append(offer.TravelerPricings, pricing)
+		}
+		offer.Price = OfferPrice{
+			Currency:   "EUR",
+			Total:      fmt.Sprintf("%.2f", total),
+			Base:       fmt.Sprintf("%.2f", base),
+			GrandTotal: fmt.Sprintf("%.2f", total),
+		}
+		resp.Data = append(resp.Data, offer)
+	}
+
+	resp.Meta.Count = len(resp.Data)
+	return resp
+}
+
+// fakeDuration formats minutes as an ISO 8601 duration
+func fakeDuration(minutes int) string {
+	return fmt.Sprintf("PT%dH%dM", minutes/60, minutes%60)
+}
+
+// fakeJSON wraps a value into an HTTP response
+func fakeJSON(req *http.Request, status int, v interface{}) (*http.Response, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// fakeAirport spreads the fake offers over the requested airport and its alternatives
+func fakeAirport(code string, alternatives []string, offer int) string {
+	airports := append([]string{code}, alternatives...)
+	return airports[offer%len(airports)]
+}
@@ -0,0 +1,392 @@
+package amadeus
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+	"github.com/Yordi-SE/FlightSearch/providers/locations"
+)
+
+// Traveler types used by the Amadeus API, keyed by our passenger type codes
+var travelerTypes = map[string]string{
+	domain.PassengerAdult:  "ADULT",
+	domain.PassengerChild:  "CHILD",
+	domain.PassengerChild6: "CHILD",
+	domain.PassengerInfant: "HELD_INFANT",
+}
+
+// Our passenger type codes, keyed by Amadeus traveler type
+var passengerTypes = map[string]string{
+	"ADULT":       domain.PassengerAdult,
+	"CHILD":       domain.PassengerChild,
+	"HELD_INFANT": domain.PassengerInfant,
+}
+
+// Cabin codes, keyed by Amadeus cabin names
+var cabinCodes = map[string]string{
+	"ECONOMY":         domain.CabinEconomy,
+	"PREMIUM_ECONOMY": domain.CabinPremiumEconomy,
+	"BUSINESS":        domain.CabinBusiness,
+	"FIRST":           domain.CabinFirst,
+}
+
+// searchAirports splits the airports of a location into the main one and its alternatives
+func searchAirports(code string, airports []string) (string, []string) {
+	if len(airports) == 0 {
+		return code, nil
+	}
+	return airports[0], airports[1:]
+}
+
+// BuildAmadeusRequest constructs the flight offers search payload
+// Args:
+//
+//	req - The provider-neutral search criteria
+//
+// Returns:
+//
+//	Formatted Amadeus request structure
+func BuildAmadeusRequest(req *domain.SearchQuery) FlightOffersSearchRequest {
+	// Cities and lists of airports are sent as their first airport with the
+	// others as alternatives, so every offer names a real airport
+	origin, alternativeOrigins := searchAirports(req.Origin, req.OriginAirports)
+	destination, alternativeDestinations := searchAirports(req.Destination, req.DestinationAirports)
+	outbound := OriginDestination{
+		ID:                      "1",
+		OriginLocationCode:      origin,
+		DestinationLocationCode: destination,
+		AlternativeOrigins:      alternativeOrigins,
+		AlternativeDestinations: alternativeDestinations,
+		DepartureDateTimeRange:  DateTimeRange{Date: req.DepartureDate},
+	}
+	originDest := []OriginDestination{outbound}
+	if req.TripType == domain.TripRoundTrip {
+		originDest = append(originDest, OriginDestination{
+			ID:                      "2",
+			OriginLocationCode:      destination,
+			DestinationLocationCode: origin,
+			AlternativeOrigins:      alternativeDestinations,
+			AlternativeDestinations: alternativeOrigins,
+			DepartureDateTimeRange:  DateTimeRange{Date: req.ReturnDate},
+		})
+	}
+
+	// Amadeus wants one traveler per person; infants ride on an adult's lap
+	travelers := []Traveler{}
+	adults := []string{}
+	for _, p := range req.Passengers {
+		for i := 0; i < p.Count; i++ {
+			id := strconv.Itoa(len(travelers) + 1)
+			traveler := Traveler{ID: id, TravelerType: travelerTypes[p.Type]}
+			if p.Type == domain.PassengerAdult {
+				adults = append(adults, id)
+			}
+			travelers = append(travelers, traveler)
+		}
+	}
+	infant := 0
+	for i := range travelers {
+		if travelers[i].TravelerType == "HELD_INFANT" && infant < len(adults) {
+			travelers[i].AssociatedID = adults[infant]
+			infant++
+		}
+	}
+
+	return FlightOffersSearchRequest{
+		OriginDestinations: originDest,
+		Travelers:          travelers,
+		Sources:            []string{"GDS"},
+		SearchCriteria:     SearchCriteria{MaxFlightOffers: 50},
+	}
+}
+
+// ParseAmadeusResponse converts a flight offers response into provider-neutral offers
+// Offers that cannot be parsed are skipped and reported as warnings.
+// Args:
+//
+//	resp - The raw response from the Amadeus API
+//	req - The original search criteria
+//
+// Returns:
+//
+//	Pointer to SearchResult containing parsed offers and any error encountered
+func ParseAmadeusResponse(resp FlightOffersSearchResponse, req *domain.SearchQuery) (*domain.SearchResult, error) {
+	result := &domain.SearchResult{}
+	for _, offer := range resp.Data {
+		parsed, err := parseOffer(offer)
+		if err != nil {
+			result.Warnings = append(result.Warnings, domain.Warning{
+				Code:    domain.WarningItinerarySkipped,
+				Message: err.Error(),
+				OfferID: offer.ID,
+			})
+			continue
+		}
+		if sum, mismatch := parsed.PassengerFaresMismatch(); mismatch {
+			result.Warnings = append(result.Warnings, domain.Warning{
+				Code:    domain.WarningPriceMismatch,
+				Message: fmt.Sprintf("passenger fares add up to %.2f, total is %.2f %s", sum, parsed.Price.Total, parsed.Price.Currency),
+				OfferID: parsed.ID,
+			})
+		}
+		result.Offers = append(result.Offers, parsed)
+	}
+
+	sort.SliceStable(result.Offers, func(i, j int) bool {
+		return result.Offers[i].Price.Total < result.Offers[j].Price.Total
+	})
+	return result, nil
+}
+
+// parseOffer maps a single Amadeus offer onto a domain offer
+func parseOffer(offer FlightOffer) (domain.Offer, error) {
+	price, err := priceFromAmadeus(offer.Price)
+	if err != nil {
+		return domain.Offer{}, err
+	}
+
+	// Amadeus prices every traveler separately; like Sabre we report one entry per
+	// passenger type, carrying the number of travelers of that type
+	typeCounts := make(map[string]int)
+	for _, tp := range offer.TravelerPricings {
+		typeCounts[tp.TravelerType]++
+	}
+
+	// Index fare details per segment so each segment can list its passenger types
+	details := make(map[string][]segmentTraveler)
+	seen := make(map[string]bool)
+	for _, tp := range offer.TravelerPricings {
+		if seen[tp.TravelerType] {
+			continue
+		}
+		seen[tp.TravelerType] = true
+		for _, fd := range tp.FareDetailsBySegment {
+			details[fd.SegmentID] = append(details[fd.SegmentID], segmentTraveler{
+				passengerType:   passengerTypes[tp.TravelerType],
+				passengerNumber: typeCounts[tp.TravelerType],
+				detail:          fd,
+			})
+		}
+	}
+
+	legs := make([]domain.Leg, 0, len(offer.Itineraries))
+	for i, itin := range offer.Itineraries {
+		if len(itin.Segments) == 0 {
+			return domain.Offer{}, fmt.Errorf("itinerary %d has no segments", i)
+		}
+		first, last := itin.Segments[0], itin.Segments[len(itin.Segments)-1]
+		legDate, err := time.Parse("2006-01-02", datePart(first.Departure.At))
+		if err != nil {
+			return domain.Offer{}, fmt.Errorf("itinerary %d has invalid departure time %q", i, first.Departure.At)
+		}
+		segments := make([]domain.Segment, 0, len(itin.Segments))
+		for _, seg := range itin.Segments {
+			segment, err := segmentFromAmadeus(seg, details[seg.ID])
+			if err != nil {
+				return domain.Offer{}, err
+			}
+			setEndpointTime(&segment.Departure, seg.Departure, legDate)
+			setEndpointTime(&segment.Arrival, seg.Arrival, legDate)
+			// Amadeus counts bookable seats for the offer as a whole
+			if offer.NumberOfBookableSeats > 0 {
+				seats := offer.NumberOfBookableSeats
+				segment.SeatsAvailable = &seats
+			}
+			segments = append(segments, segment)
+		}
+		legs = append(legs, domain.Leg{
+			DepartureDate: datePart(first.Departure.At),
+			Origin:        first.Departure.IataCode,
+			Destination:   last.Arrival.IataCode,
+			ElapsedTime:   parseDuration(itin.Duration),
+			Segments:      segments,
+		})
+	}
+	if len(legs) == 0 {
+		return domain.Offer{}, fmt.Errorf("offer has no itineraries")
+	}
+
+	validating := ""
+	if len(offer.ValidatingAirlineCodes) > 0 {
+		validating = offer.ValidatingAirlineCodes[0]
+	}
+	fares, err := passengerFaresFromAmadeus(offer.TravelerPricings, typeCounts)
+	if err != nil {
+		return domain.Offer{}, err
+	}
+	parsed := domain.Offer{
+		ID:                offer.ID,
+		Legs:              legs,
+		Price:             price,
+		ValidatingCarrier: validating,
+		LastTicketDate:    offer.LastTicketingDate,
+		PassengerFares:    fares,
+	}
+	parsed.ComputeDurations()
+	parsed.ComputeBaggagePolicies()
+	return parsed, nil
+}
+
+// passengerFaresFromAmadeus reports one fare per traveler type, priced like its first traveler
+// Amadeus does not itemize taxes or say whether the fare is refundable here.
+func passengerFaresFromAmadeus(pricings []TravelerPricing, typeCounts map[string]int) ([]domain.PassengerFare, error) {
+	var fares []domain.PassengerFare
+	seen := make(map[string]bool)
+	for _, tp := range pricings {
+		if seen[tp.TravelerType] {
+			continue
+		}
+		seen[tp.TravelerType] = true
+		price, err := priceFromAmadeus(tp.Price)
+		if err != nil {
+			return nil, fmt.Errorf("traveler %s: %v", tp.TravelerID, err)
+		}
+		fare := domain.PassengerFare{
+			PassengerType:  passengerTypes[tp.TravelerType],
+			PassengerCount: typeCounts[tp.TravelerType],
+			Base:           price.Base,
+			TotalTaxes:     domain.RoundAmount(price.Taxes, price.Currency),
+			Total:          price.Total,
+			Currency:       price.Currency,
+		}
+		for _, fd := range tp.FareDetailsBySegment {
+			if n := len(fare.FareBasisCodes); fd.FareBasis != "" && (n == 0 || fare.FareBasisCodes[n-1] != fd.FareBasis) {
+				fare.FareBasisCodes = append(fare.FareBasisCodes, fd.FareBasis)
+			}
+		}
+		fares = append(fares, fare)
+	}
+	return fares, nil
+}
+
+// setEndpointTime places an Amadeus local time in the airport's time zone
+// Amadeus reports times without a UTC offset; airports missing from the
+// reference data keep only their local time.
+func setEndpointTime(endpoint *domain.Endpoint, point FlightPoint, legDate time.Time) {
+	t, err := locations.AirportTime(point.IataCode, datePart(point.At), timePart(point.At))
+	if err != nil {
+		return
+	}
+	endpoint.SetDateTime(t, legDate)
+}
+
+// segmentTraveler ties a passenger type's fare details to a segment
+type segmentTraveler struct {
+	passengerType   string
+	passengerNumber int
+	detail          FareDetailBySegment
+}
+
+func segmentFromAmadeus(seg OfferSegment, travelers []segmentTraveler) (domain.Segment, error) {
+	number, err := strconv.Atoi(seg.Number)
+	if err != nil {
+		return domain.Segment{}, fmt.Errorf("segment %s has invalid flight number %q", seg.ID, seg.Number)
+	}
+	operating, operatingNumber := seg.CarrierCode, number
+	if seg.Operating != nil && seg.Operating.CarrierCode != "" {
+		operating = seg.Operating.CarrierCode
+		if n, err := strconv.Atoi(seg.Operating.Number); err == nil {
+			operatingNumber = n
+		}
+	}
+
+	passengers := make([]domain.SegmentPassenger, 0, len(travelers))
+	for _, t := range travelers {
+		passenger := domain.SegmentPassenger{
+			PassengerType:   t.passengerType,
+			PassengerNumber: t.passengerNumber,
+			FareComponent: &domain.FareComponent{
+				FareBasisCode: t.detail.FareBasis,
+				BeginAirport:  seg.Departure.IataCode,
+				EndAirport:    seg.Arrival.IataCode,
+				CabinCode:     cabinCodes[t.detail.Cabin],
+			},
+		}
+		if bags := t.detail.IncludedCheckedBags; bags != nil {
+			passenger.Baggage = []domain.BaggageAllowance{{
+				Pieces: bags.Quantity,
+				Weight: bags.Weight,
+				Unit:   strings.ToLower(bags.WeightUnit),
+			}}
+		}
+		passengers = append(passengers, passenger)
+	}
+
+	// The first passenger type describes the segment's booking class and cabin
+	var bookingClass, cabin string
+	if len(travelers) > 0 {
+		bookingClass, cabin = travelers[0].detail.Class, cabinCodes[travelers[0].detail.Cabin]
+	}
+
+	return domain.Segment{
+		Departure:             endpointFromAmadeus(seg.Departure),
+		Arrival:               endpointFromAmadeus(seg.Arrival),
+		MarketingCarrier:      seg.CarrierCode,
+		MarketingFlightNumber: number,
+		OperatingCarrier:      operating,
+		OperatingFlightNumber: operatingNumber,
+		Equipment:             seg.Aircraft.Code,
+		ElapsedTime:           parseDuration(seg.Duration),
+		StopCount:             seg.NumberOfStops,
+		ETicketable:           true,
+		BookingClass:          bookingClass,
+		Cabin:                 cabin,
+		Passengers:            passengers,
+	}, nil
+}
+
+func endpointFromAmadeus(point FlightPoint) domain.Endpoint {
+	return domain.Endpoint{
+		Airport:  point.IataCode,
+		Terminal: point.Terminal,
+		Time:     timePart(point.At),
+	}
+}
+
+func priceFromAmadeus(price OfferPrice) (domain.Price, error) {
+	total, err := strconv.ParseFloat(price.Total, 64)
+	if err != nil {
+		return domain.Price{}, fmt.Errorf("invalid total price %q", price.Total)
+	}
+	base, err := strconv.ParseFloat(price.Base, 64)
+	if err != nil {
+		return domain.Price{}, fmt.Errorf("invalid base price %q", price.Base)
+	}
+	return domain.Price{
+		Total:    total,
+		Base:     base,
+		Taxes:    total - base,
+		Currency: price.Currency,
+	}, nil
+}
+
+// datePart returns the YYYY-MM-DD part of an ISO local date time
+func datePart(at string) string {
+	date, _, _ := strings.Cut(at, "T")
+	return date
+}
+
+// timePart returns the HH:MM:SS part of an ISO local date time
+func timePart(at string) string {
+	_, t, _ := strings.Cut(at, "T")
+	return t
+}
+
+var durationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?)?$`)
+
+// parseDuration converts an ISO 8601 duration such as PT6H20M into minutes
+func parseDuration(d string) int {
+	m := durationPattern.FindStringSubmatch(d)
+	if m == nil {
+		return 0
+	}
+	days, _ := strconv.Atoi(m[1])
+	hours, _ := strconv.Atoi(m[2])
+	minutes, _ := strconv.Atoi(m[3])
+	return days*24*60 + hours*60 + minutes
+}
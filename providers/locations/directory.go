@@ -0,0 +1,261 @@
+package locations
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// locationsCSV is the airport and city reference data compiled into the binary
+// Searches are validated against it, so it must list every airport sold.
+//
+//go:embed locations.csv
+var locationsCSV string
+
+// Columns of locations.csv
+const (
+	colType = iota
+	colCode
+	colName
+	colCityCode
+	colCity
+	colCountry
+	colTimeZone
+	colLatitude
+	colLongitude
+	columnCount
+)
+
+// Match ranks used to order search results, best first
+const (
+	rankCode = iota
+	rankCodePrefix
+	rankName
+	rankCity
+	rankNameWord
+	rankCityWord
+	rankContains
+)
+
+// Directory serves airport and city reference data from the embedded dataset
+type Directory struct {
+	airports  map[string]*domain.Location
+	cities    map[string]*domain.Location
+	locations []*domain.Location
+}
+
+// NewDirectory creates and initializes a new Directory from the embedded dataset
+// Returns:
+//
+//	Pointer to a new Directory instance or an error if the dataset is malformed
+func NewDirectory() (*Directory, error) {
+	return parseDirectory(locationsCSV)
+}
+
+// parseDirectory builds a Directory from CSV reference data
+func parseDirectory(data string) (*Directory, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locations: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("locations dataset is empty")
+	}
+
+	d := &Directory{
+		airports: make(map[string]*domain.Location),
+		cities:   make(map[string]*domain.Location),
+	}
+	// The first record is the header
+	for i, record := range records[1:] {
+		loc, err := parseLocation(record)
+		if err != nil {
+			return nil, fmt.Errorf("locations line %d: %v", i+2, err)
+		}
+		index := d.airports
+		if loc.Type == domain.LocationCity {
+			index = d.cities
+		}
+		if _, ok := index[loc.Code]; ok {
+			return nil, fmt.Errorf("locations line %d: duplicate %s %s", i+2, loc.Type, loc.Code)
+		}
+		index[loc.Code] = loc
+		d.locations = append(d.locations, loc)
+	}
+
+	// Link every airport to the city it serves
+	for _, loc := range d.locations {
+		if loc.Type != domain.LocationAirport {
+			continue
+		}
+		if city, ok := d.cities[loc.CityCode]; ok {
+			city.Airports = append(city.Airports, loc.Code)
+		}
+	}
+	return d, nil
+}
+
+// parseLocation converts a CSV record into a Location
+func parseLocation(record []string) (*domain.Location, error) {
+	if len(record) != columnCount {
+		return nil, fmt.Errorf("expected %d columns, got %d", columnCount, len(record))
+	}
+	loc := &domain.Location{
+		Type:     record[colType],
+		Code:     record[colCode],
+		Name:     record[colName],
+		CityCode: record[colCityCode],
+		City:     record[colCity],
+		Country:  record[colCountry],
+		TimeZone: record[colTimeZone],
+	}
+	if loc.Type != domain.LocationAirport && loc.Type != domain.LocationCity {
+		return nil, fmt.Errorf("invalid type %q", loc.Type)
+	}
+	if len(loc.Code) != 3 || len(loc.CityCode) != 3 {
+		return nil, fmt.Errorf("invalid code %q or city code %q", loc.Code, loc.CityCode)
+	}
+	var err error
+	if loc.Latitude, err = strconv.ParseFloat(record[colLatitude], 64); err != nil {
+		return nil, fmt.Errorf("invalid latitude %q", record[colLatitude])
+	}
+	if loc.Longitude, err = strconv.ParseFloat(record[colLongitude], 64); err != nil {
+		return nil, fmt.Errorf("invalid longitude %q", record[colLongitude])
+	}
+	return loc, nil
+}
+
+// Lookup returns the airport or city with the given IATA code
+// Some codes name both an airport and its city (e.g. SHA); the airport is returned
+// then, and City returns the city.
+func (d *Directory) Lookup(code string) (*domain.Location, bool) {
+	code = strings.ToUpper(code)
+	if loc, ok := d.airports[code]; ok {
+		return copyLocation(loc), true
+	}
+	if loc, ok := d.cities[code]; ok {
+		return copyLocation(loc), true
+	}
+	return nil, false
+}
+
+// City returns the city with the given IATA code, even when an airport shares the code
+func (d *Directory) City(code string) (*domain.Location, bool) {
+	if loc, ok := d.cities[strings.ToUpper(code)]; ok {
+		return copyLocation(loc), true
+	}
+	return nil, false
+}
+
+// Search returns the locations matching a free text query, best matches first
+// Exact and prefix code matches rank above name matches, which rank above city
+// matches; within a rank cities come before airports.
+// Args:
+//
+//	query - Code, airport name or city name typed by the user
+//	limit - Maximum number of locations to return
+//
+// Returns:
+//
+//	The matching locations, at most limit of them
+func (d *Directory) Search(query string, limit int) []domain.Location {
+	q := fold(strings.TrimSpace(query))
+	if q == "" || limit <= 0 {
+		return nil
+	}
+
+	type match struct {
+		loc  *domain.Location
+		rank int
+	}
+	var matches []match
+	for _, loc := range d.locations {
+		if rank, ok := rankLocation(loc, q); ok {
+			matches = append(matches, match{loc: loc, rank: rank})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.loc.Type != b.loc.Type {
+			return a.loc.Type == domain.LocationCity
+		}
+		return a.loc.Code < b.loc.Code
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	results := make([]domain.Location, 0, len(matches))
+	for _, m := range matches {
+		results = append(results, *copyLocation(m.loc))
+	}
+	return results
+}
+
+// rankLocation reports how well a location matches a folded query
+func rankLocation(loc *domain.Location, q string) (int, bool) {
+	code := strings.ToLower(loc.Code)
+	name := fold(loc.Name)
+	city := fold(loc.City)
+	switch {
+	case code == q:
+		return rankCode, true
+	case strings.HasPrefix(code, q):
+		return rankCodePrefix, true
+	case strings.HasPrefix(name, q):
+		return rankName, true
+	case strings.HasPrefix(city, q):
+		return rankCity, true
+	case hasWordPrefix(name, q):
+		return rankNameWord, true
+	case hasWordPrefix(city, q):
+		return rankCityWord, true
+	case len(q) >= 3 && strings.Contains(name, q):
+		return rankContains, true
+	}
+	return 0, false
+}
+
+// hasWordPrefix reports whether any word of s after the first starts with prefix
+func hasWordPrefix(s, prefix string) bool {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '/' || r == '.'
+	})
+	for _, word := range words[min(1, len(words)):] {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// accentFolder strips the diacritics found in the dataset so "sao" finds "São"
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "î", "i", "ï", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// fold lower-cases s and strips its accents for comparison
+func fold(s string) string {
+	return accentFolder.Replace(strings.ToLower(s))
+}
+
+// copyLocation returns a copy the caller may modify without touching the directory
+func copyLocation(loc *domain.Location) *domain.Location {
+	c := *loc
+	c.Airports = append([]string(nil), loc.Airports...)
+	return &c
+}
@@ -0,0 +1,28 @@
+package locations
+
+import "math"
+
+// earthRadiusKm is the mean radius of the Earth
+const earthRadiusKm = 6371.0
+
+// Distance returns the great-circle distance in kilometres between two airports
+// of the embedded dataset
+func Distance(from, to string) (float64, bool) {
+	a, ok := sharedLookup(from)
+	if !ok {
+		return 0, false
+	}
+	b, ok := sharedLookup(to)
+	if !ok {
+		return 0, false
+	}
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat, dLon := lat2-lat1, radians(b.Longitude-a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h)), true
+}
+
+// radians converts degrees to radians
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,103 @@
+package locations
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // Airport time zones must resolve without a system zoneinfo database
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// sharedDirectory is the embedded dataset behind the package-level lookups, parsed on first use
+var (
+	sharedOnce      sync.Once
+	sharedDirectory *Directory
+	sharedErr       error
+)
+
+// shared returns the shared embedded dataset
+func shared() (*Directory, bool) {
+	sharedOnce.Do(func() {
+		sharedDirectory, sharedErr = NewDirectory()
+	})
+	return sharedDirectory, sharedErr == nil
+}
+
+// sharedLookup finds an airport or city in the shared embedded dataset
+func sharedLookup(code string) (*domain.Location, bool) {
+	d, ok := shared()
+	if !ok {
+		return nil, false
+	}
+	return d.Lookup(code)
+}
+
+// TimeZone returns the time zone of an airport or city in the embedded dataset
+// Adapters given a Directory should use its TimeZone method instead.
+func TimeZone(code string) (*time.Location, bool) {
+	d, ok := shared()
+	if !ok {
+		return nil, false
+	}
+	return d.TimeZone(code)
+}
+
+// AirportTime resolves a local date and clock time at an airport of the embedded dataset
+// Adapters given a Directory should use its AirportTime method instead.
+func AirportTime(code, date, clock string) (time.Time, error) {
+	return airportTime(TimeZone, code, date, clock)
+}
+
+// TimeZone returns the time zone of an airport or city
+func (d *Directory) TimeZone(code string) (*time.Location, bool) {
+	loc, ok := d.Lookup(code)
+	if !ok || loc.TimeZone == "" {
+		return nil, false
+	}
+	zone, err := time.LoadLocation(loc.TimeZone)
+	if err != nil {
+		return nil, false
+	}
+	return zone, true
+}
+
+// AirportTime resolves a local date and clock time at an airport into an absolute time
+// A UTC offset given with the clock time (e.g. 18:30:00-04:00) is used as is;
+// without one the airport's time zone from the reference data decides.
+// Args:
+//
+//	code - IATA code of the airport
+//	date - Local date, format YYYY-MM-DD
+//	clock - Local time, format HH:MM or HH:MM:SS with an optional UTC offset
+//
+// Returns:
+//
+//	The absolute time, in the airport's local offset, or an error if it cannot be resolved
+func (d *Directory) AirportTime(code, date, clock string) (time.Time, error) {
+	return airportTime(d.TimeZone, code, date, clock)
+}
+
+// airportTime resolves an airport's local time using the given time zone lookup
+func airportTime(timeZone func(code string) (*time.Location, bool), code, date, clock string) (time.Time, error) {
+	if len(clock) == len("15:04") {
+		clock += ":00"
+	}
+	value := date + "T" + clock
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if strings.ContainsAny(clock[min(len(clock), len("15:04:05")):], "+-Z") {
+		return time.Time{}, fmt.Errorf("invalid time %q on %s at %s", clock, date, code)
+	}
+	zone, ok := timeZone(code)
+	if !ok {
+		return time.Time{}, fmt.Errorf("no time zone known for %s", code)
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04:05", value, zone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q on %s at %s", clock, date, code)
+	}
+	return t, nil
+}
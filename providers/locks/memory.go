@@ -0,0 +1,71 @@
+package locks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// lease is a held lock
+type lease struct {
+	token   string
+	expires time.Time
+}
+
+// MemoryStore grants locks held in process memory
+// Locks are only exclusive within one instance; deployments running several
+// instances need a shared store behind the same interface.
+type MemoryStore struct {
+	mu     sync.Mutex
+	leases map[string]lease
+	now    func() time.Time
+}
+
+// NewMemoryStore creates and initializes a new MemoryStore
+// Returns:
+//
+//	Pointer to a new MemoryStore instance
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		leases: make(map[string]lease),
+		now:    time.Now,
+	}
+}
+
+// Acquire takes the lock on key unless another unexpired lease holds it
+// Expired leases are dropped as they are found, so the store only keeps held locks.
+func (m *MemoryStore) Acquire(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", false, fmt.Errorf("failed to create lock token: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	for k, l := range m.leases {
+		if now.After(l.expires) {
+			delete(m.leases, k)
+		}
+	}
+	if _, held := m.leases[key]; held {
+		return "", false, nil
+	}
+	token := hex.EncodeToString(raw)
+	m.leases[key] = lease{token: token, expires: now.Add(ttl)}
+	return token, true, nil
+}
+
+// Release drops the lock on key if the token still holds it
+// A lease that expired and was taken by someone else is left alone.
+func (m *MemoryStore) Release(ctx context.Context, key, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.leases[key]; ok && l.token == token {
+		delete(m.leases, key)
+	}
+	return nil
+}
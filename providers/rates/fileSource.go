@@ -0,0 +1,92 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// FileSource serves exchange rates from a local JSON file
+// The file is re-read when it changed on disk, at most once per reload interval,
+// so rates can be updated without restarting the service.
+type FileSource struct {
+	Path           string        // Location of the rates file
+	ReloadInterval time.Duration // Minimum time between checks for a newer file
+
+	mu        sync.Mutex
+	table     *domain.RateTable
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// NewFileSource creates and initializes a new FileSource instance
+// Args:
+//
+//	path - Location of the rates file
+//	reloadInterval - Minimum time between checks for a newer file
+//
+// Returns:
+//
+//	Pointer to a new FileSource instance
+func NewFileSource(path string, reloadInterval time.Duration) *FileSource {
+	return &FileSource{
+		Path:           path,
+		ReloadInterval: reloadInterval,
+	}
+}
+
+// Rates returns the current rate table, reloading the file when it changed
+// When a reload fails the previously loaded rates keep being served.
+func (s *FileSource) Rates(ctx context.Context) (*domain.RateTable, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.table != nil && time.Since(s.checkedAt) < s.ReloadInterval {
+		return s.table, nil
+	}
+	s.checkedAt = time.Now()
+
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return s.stale(fmt.Errorf("failed to read rates file: %v", err))
+	}
+	if s.table != nil && !info.ModTime().After(s.modTime) {
+		return s.table, nil
+	}
+
+	table, err := load(s.Path)
+	if err != nil {
+		return s.stale(err)
+	}
+	s.table, s.modTime = table, info.ModTime()
+	return s.table, nil
+}
+
+// stale returns the last loaded rates, or err when nothing was loaded yet
+func (s *FileSource) stale(err error) (*domain.RateTable, error) {
+	if s.table != nil {
+		return s.table, nil
+	}
+	return nil, err
+}
+
+// load reads and checks a rates file
+func load(path string) (*domain.RateTable, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %v", err)
+	}
+	var table domain.RateTable
+	if err := json.Unmarshal(raw, &table); err != nil {
+		return nil, fmt.Errorf("invalid rates file %s: %v", path, err)
+	}
+	if len(table.Base) != 3 || len(table.Rates) == 0 {
+		return nil, fmt.Errorf("invalid rates file %s: base currency and rates are required", path)
+	}
+	return &table, nil
+}
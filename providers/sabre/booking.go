@@ -0,0 +1,246 @@
+package sabre
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// createPNRPath is the Create Passenger Name Record endpoint, relative to the base URL
+const createPNRPath = "/v2.4.0/passenger/records?mode=create"
+
+// Segment statuses that mean the airline did not confirm the sale
+var haltOnStatus = []HaltOnStatus{
+	{Code: "HL"}, {Code: "KK"}, {Code: "LL"}, {Code: "NN"}, {Code: "NO"}, {Code: "UC"}, {Code: "US"},
+}
+
+// BuildCreatePNRRequest constructs the Create Passenger Name Record payload
+// Args:
+//
+//	req - The selected itinerary, travelers and contact details
+//	PCC - Pseudo City Code the reservation is created in
+//
+// Returns:
+//
+//	Formatted Sabre request structure
+func BuildCreatePNRRequest(req *domain.BookingRequest, PCC string) CreatePNRRequestFormat {
+	// Name numbers follow Sabre's "n.1" scheme; lap infants carry their age in months
+	// and are not counted in the number of seats sold
+	names := make([]PersonName, 0, len(req.Travelers))
+	advance := make([]AdvancePassenger, 0, len(req.Travelers))
+	secure := make([]SecureFlight, 0, len(req.Travelers))
+	seated := 0
+	for i, t := range req.Travelers {
+		nameNumber := strconv.Itoa(i+1) + ".1"
+		name := PersonName{
+			NameNumber:    nameNumber,
+			PassengerType: t.Type,
+			GivenName:     strings.ToUpper(t.GivenName),
+			Surname:       strings.ToUpper(t.Surname),
+		}
+		if t.Type == domain.PassengerInfant {
+			name.Infant = true
+			name.NameReference = "I" + infantAgeMonths(t.DateOfBirth)
+		} else {
+			seated++
+		}
+		names = append(names, name)
+
+		ssrName := SSRPersonName{
+			NameNumber:  nameNumber,
+			GivenName:   name.GivenName,
+			Surname:     name.Surname,
+			DateOfBirth: t.DateOfBirth,
+			Gender:      infantGender(t),
+		}
+		secure = append(secure, SecureFlight{PersonName: ssrName, SegmentNumber: "A"})
+		if t.Document != nil {
+			advance = append(advance, AdvancePassenger{
+				Document: Document{
+					Number:             t.Document.Number,
+					IssueCountry:       t.Document.IssuingCountry,
+					NationalityCountry: t.Document.Nationality,
+					ExpirationDate:     t.Document.ExpiryDate,
+					Type:               t.Document.Type,
+				},
+				PersonName:    ssrName,
+				SegmentNumber: "A",
+			})
+		}
+	}
+
+	segments := []BookFlightSegment{}
+	for _, leg := range req.Itinerary.Legs {
+		for _, seg := range leg.Segments {
+			segment := BookFlightSegment{
+				DepartureDateTime:   seg.DepartureDateTime,
+				ArrivalDateTime:     seg.ArrivalDateTime,
+				FlightNumber:        strconv.Itoa(seg.FlightNumber),
+				NumberInParty:       strconv.Itoa(seated),
+				ResBookDesigCode:    seg.BookingClass,
+				Status:              "NN",
+				OriginLocation:      LocationCode{LocationCode: seg.Origin},
+				DestinationLocation: LocationCode{LocationCode: seg.Destination},
+				MarketingAirline: MarketingAirline{
+					Code:         seg.MarketingCarrier,
+					FlightNumber: strconv.Itoa(seg.FlightNumber),
+				},
+			}
+			if seg.OperatingCarrier != "" && seg.OperatingCarrier != seg.MarketingCarrier {
+				segment.OperatingAirline = &AirlineCode{Code: seg.OperatingCarrier}
+			}
+			segments = append(segments, segment)
+		}
+	}
+
+	pricing := []PricingPassengerType{}
+	for _, p := range req.Itinerary.Passengers {
+		pricing = append(pricing, PricingPassengerType{Code: p.Type, Quantity: strconv.Itoa(p.Count)})
+	}
+
+	var special *SpecialReqDetails
+	if len(advance) > 0 || len(secure) > 0 {
+		special = &SpecialReqDetails{SpecialService: SpecialService{SpecialServiceInfo: SpecialServiceInfo{
+			AdvancePassenger: advance,
+			SecureFlight:     secure,
+		}}}
+	}
+
+	customer := CustomerInfo{
+		ContactNumbers: ContactNumbers{ContactNumber: []ContactNumber{
+			{NameNumber: "1.1", Phone: req.Contact.Phone, PhoneUseType: "H"},
+		}},
+		PersonName: names,
+	}
+	if req.Contact.Email != "" {
+		customer.Email = []EmailAddress{{Address: req.Contact.Email, NameNumber: "1.1", Type: "TO"}}
+	}
+
+	return CreatePNRRequestFormat{
+		CreatePassengerNameRecordRQ: CreatePassengerNameRecordRQ{
+			Version:             "2.4.0",
+			TargetCity:          PCC,
+			HaltOnAirPriceError: true,
+			TravelItineraryAddInfo: TravelItineraryAddInfo{
+				AgencyInfo:   AgencyInfo{Ticketing: TicketingArrangement{TicketType: "7TAW"}},
+				CustomerInfo: customer,
+			},
+			AirBook: AirBook{
+				HaltOnStatus:                 haltOnStatus,
+				OriginDestinationInformation: BookOriginDestinationInfo{FlightSegment: segments},
+				RedisplayReservation:         AirBookRedisplayReservation{NumAttempts: 3, WaitInterval: 3000},
+			},
+			AirPrice: []AirPriceRQ{{
+				PriceRequestInformation: PriceRequestInformation{
+					Retain: true,
+					OptionalQualifiers: OptionalQualifiers{
+						PricingQualifiers: PricingQualifiers{PassengerType: pricing},
+					},
+				},
+			}},
+			SpecialReqDetails: special,
+			PostProcessing: PostProcessing{
+				EndTransaction: EndTransaction{Source: EndTransactionSource{ReceivedFrom: "FLIGHTSEARCH API"}},
+			},
+		},
+	}
+}
+
+// CreateBooking sells, prices and stores a reservation
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The selected itinerary, travelers and contact details
+//
+// Returns:
+//
+//	Pointer to the held Booking or an error if Sabre rejected the reservation
+func (c *SabreClient) CreateBooking(ctx context.Context, req *domain.BookingRequest) (*domain.Booking, error) {
+	body, err := c.doRequest(ctx, "POST", c.BaseURL+createPNRPath, BuildCreatePNRRequest(req, c.PCC))
+	if err != nil {
+		return nil, err
+	}
+
+	var pnrResp CreatePNRResponseFormat
+	if err := json.Unmarshal(body, &pnrResp); err != nil {
+		return nil, fmt.Errorf("invalid response format from Sabre API: %v", err)
+	}
+	rs := pnrResp.CreatePassengerNameRecordRS
+
+	if rs.ApplicationResults.Status != "Complete" || rs.ItineraryRef.ID == "" {
+		return nil, applicationError("booking", rs.ApplicationResults)
+	}
+
+	booking := &domain.Booking{
+		RecordLocator: rs.ItineraryRef.ID,
+		Source:        c.Name(),
+		Status:        domain.BookingHeld,
+		Travelers:     req.Travelers,
+	}
+	if len(rs.AirPrice) > 0 {
+		quote := rs.AirPrice[0].PriceQuote
+		if len(quote.MiscInformation.HeaderInformation) > 0 {
+			header := quote.MiscInformation.HeaderInformation[0]
+			booking.TicketingTimeLimit = header.LastTicketingDate
+			if len(header.ValidatingCarrier) > 0 {
+				booking.ValidatingCarrier = header.ValidatingCarrier[0].Code
+			}
+		}
+		if total, err := strconv.ParseFloat(quote.PricedItinerary.TotalAmount, 64); err == nil {
+			booking.Price = &domain.Price{Total: total, Currency: quote.PricedItinerary.CurrencyCode}
+		}
+	}
+	return booking, nil
+}
+
+// applicationError turns Sabre application results into an error
+// Unconfirmed segment sales are reported as domain.ErrNoAvailability.
+func applicationError(operation string, results ApplicationResults) error {
+	var messages []string
+	for _, result := range results.Error {
+		for _, system := range result.SystemSpecific {
+			for _, msg := range system.Message {
+				messages = append(messages, msg.Content)
+			}
+		}
+	}
+	text := strings.Join(messages, "; ")
+	if text == "" {
+		text = "status " + results.Status
+	}
+	upper := strings.ToUpper(text)
+	if strings.Contains(upper, "UNABLE TO SELL") || strings.Contains(upper, "HALT ON STATUS") || strings.Contains(upper, "NO AVAIL") {
+		return fmt.Errorf("%w: %s", domain.ErrNoAvailability, text)
+	}
+	return fmt.Errorf("sabre %s failed: %s", operation, text)
+}
+
+// infantAgeMonths returns the infant's age in months as Sabre's name reference expects
+func infantAgeMonths(dateOfBirth string) string {
+	dob, err := time.Parse("2006-01-02", dateOfBirth)
+	if err != nil {
+		return "0"
+	}
+	now := time.Now()
+	months := (now.Year()-dob.Year())*12 + int(now.Month()) - int(dob.Month())
+	if now.Day() < dob.Day() {
+		months--
+	}
+	if months < 0 {
+		months = 0
+	}
+	return strconv.Itoa(months)
+}
+
+// infantGender adds Sabre's infant marker to the gender of lap infants
+func infantGender(t domain.Traveler) string {
+	if t.Type == domain.PassengerInfant {
+		return t.Gender + "I"
+	}
+	return t.Gender
+}
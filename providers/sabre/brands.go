@@ -0,0 +1,130 @@
+package sabre
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// Kinds of brand features shown in the fare family comparison
+const (
+	featureSeat   = "seat"
+	featureChange = "change"
+	featureRefund = "refund"
+)
+
+// fareFamilyFromSabre describes the brand of one pricing option
+// Unbranded pricing options return nil.
+func fareFamilyFromSabre(pricing PricingInfo, offer domain.Offer, bags []domain.BaggageAllowance, descs responseDescs) *domain.FareFamily {
+	// The first passenger's fare components carry the brand for the whole party
+	passenger := pricing.Fare.PassengerInfoList[0].PassengerInfo
+	if len(passenger.FareComponents) == 0 {
+		return nil
+	}
+	brand := descs.fares[passenger.FareComponents[0].Ref].Brand
+	if brand == nil || brand.Code == "" {
+		return nil
+	}
+
+	family := &domain.FareFamily{
+		OfferID:       offer.ID,
+		BrandCode:     brand.Code,
+		BrandName:     brand.BrandName,
+		Price:         offer.Price,
+		SeatSelection: domain.FeatureUnknown,
+		Changeable:    domain.FeatureUnknown,
+		Refundable:    domain.FeatureUnknown,
+	}
+	if passenger.NonRefundable {
+		family.Refundable = domain.FeatureNotOffered
+	}
+
+	for _, bag := range bags {
+		if bag.Pieces != nil && !bag.CarryOn {
+			pieces := *bag.Pieces
+			family.CheckedBags = &pieces
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, component := range passenger.FareComponents {
+		for _, seg := range component.Segments {
+			family.BookingClasses = append(family.BookingClasses, seg.Segment.BookingCode)
+		}
+		for _, ref := range descs.fares[component.Ref].BrandFeatureRefs {
+			feature, ok := descs.brandFeatures[ref.Ref]
+			if !ok {
+				continue
+			}
+			kind := brandFeatureKind(feature)
+			if kind == "" || seen[kind] {
+				continue
+			}
+			seen[kind] = true
+			availability := featureAvailability(feature.Application)
+			switch kind {
+			case featureSeat:
+				family.SeatSelection = availability
+			case featureChange:
+				family.Changeable = availability
+			case featureRefund:
+				family.Refundable = availability
+			}
+		}
+	}
+	return family
+}
+
+// brandFeatureKind tells which comparison column a brand feature belongs to
+func brandFeatureKind(feature BrandFeatureDesc) string {
+	name := strings.ToUpper(feature.CommercialName)
+	switch {
+	case strings.Contains(name, "REFUND"):
+		return featureRefund
+	case strings.Contains(name, "CHANGE") || strings.Contains(name, "REBOOK"):
+		return featureChange
+	case feature.ServiceGroup == "SA" || strings.Contains(name, "SEAT"):
+		return featureSeat
+	}
+	return ""
+}
+
+// featureAvailability maps Sabre's application indicator onto our availability values
+func featureAvailability(application string) string {
+	switch application {
+	case "F":
+		return domain.FeatureIncluded
+	case "C":
+		return domain.FeatureChargeable
+	case "N", "D":
+		return domain.FeatureNotOffered
+	}
+	return domain.FeatureUnknown
+}
+
+// foldFareFamilies turns the brands of one itinerary into a single offer
+// The offer is priced at the cheapest brand and lists every brand with its
+// price difference from it.
+func foldFareFamilies(offers []domain.Offer, families []domain.FareFamily) domain.Offer {
+	cheapest := 0
+	for i := range offers {
+		if offers[i].Price.Total < offers[cheapest].Price.Total {
+			cheapest = i
+		}
+	}
+
+	sort.SliceStable(families, func(i, j int) bool {
+		return families[i].Price.Total < families[j].Price.Total
+	})
+	lowest := families[0].Price.Total
+	for i := range families {
+		families[i].PriceDifference = math.Round((families[i].Price.Total-lowest)*100) / 100
+	}
+
+	offer := offers[cheapest]
+	offer.FareFamilies = families
+	return offer
+}
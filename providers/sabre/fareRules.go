@@ -0,0 +1,201 @@
+package sabre
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// airRulesPath is the Air Fare Rules endpoint, relative to the base URL
+const airRulesPath = "/v2.3.0/air/fare/rules"
+
+// Patterns used to read the rule text
+var (
+	chargePattern       = regexp.MustCompile(`CHARGE\s+([A-Z]{3})\s*([0-9]+(?:\.[0-9]+)?)`)
+	daysPattern         = regexp.MustCompile(`([0-9]+)\s+DAYS?`)
+	monthsPattern       = regexp.MustCompile(`([0-9]+)\s+MONTHS?`)
+	reservationsPattern = regexp.MustCompile(`RESERVATIONS[^.]*?AT LEAST\s+([0-9]+)\s+DAYS?`)
+	ticketingPattern    = regexp.MustCompile(`TICKETING[^.]*?AT LEAST\s+([0-9]+)\s+DAYS?`)
+)
+
+// BuildAirRulesRequest constructs the Air Fare Rules payload for a fare component
+// Args:
+//
+//	query - The fare component whose rules are requested
+//
+// Returns:
+//
+//	Formatted Sabre request structure
+func BuildAirRulesRequest(query *domain.FareRulesQuery) AirRulesRequestFormat {
+	// The service only takes the month and day of travel
+	travelDate := query.DepartureDate
+	if date, err := time.Parse("2006-01-02", query.DepartureDate); err == nil {
+		travelDate = date.Format("01-02")
+	}
+
+	return AirRulesRequestFormat{
+		OTA_AirRulesRQ: OTA_AirRulesRQ{
+			Version: "2.3.0",
+			OriginDestinationInformation: RulesOriginDestination{
+				FlightSegment: RulesFlightSegment{
+					DepartureDateTime:   travelDate,
+					OriginLocation:      LocationCode{LocationCode: query.BeginAirport},
+					DestinationLocation: LocationCode{LocationCode: query.EndAirport},
+					MarketingCarrier:    AirlineCode{Code: query.GoverningCarrier},
+				},
+			},
+			RuleReqInfo: RuleReqInfo{
+				FareBasis:  FareBasisCode{Code: query.FareBasisCode},
+				RuleTariff: query.FareTariff,
+				Rule:       query.FareRule,
+			},
+		},
+	}
+}
+
+// FareRules retrieves and interprets the rules of a fare component
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	query - The fare component whose rules are requested
+//
+// Returns:
+//
+//	Pointer to FareRules with the structured categories and raw text, or an error
+func (c *SabreClient) FareRules(ctx context.Context, query *domain.FareRulesQuery) (*domain.FareRules, error) {
+	body, err := c.doRequest(ctx, "POST", c.BaseURL+airRulesPath, BuildAirRulesRequest(query))
+	if err != nil {
+		return nil, err
+	}
+
+	var rulesResp AirRulesResponseFormat
+	if err := json.Unmarshal(body, &rulesResp); err != nil {
+		return nil, fmt.Errorf("invalid response format from Sabre API: %v", err)
+	}
+	rs := rulesResp.OTA_AirRulesRS
+	if rs.ApplicationResults.Status != "Complete" {
+		return nil, applicationError("fare rules", rs.ApplicationResults)
+	}
+	return ParseFareRules(rs.FareRuleInfo.Rules.Paragraph, query), nil
+}
+
+// ParseFareRules turns rule paragraphs into structured fare rules
+// Categories we do not interpret are still returned as raw text.
+// Args:
+//
+//	paragraphs - The rule paragraphs returned by Sabre
+//	query - The fare component the rules belong to
+//
+// Returns:
+//
+//	Pointer to FareRules
+func ParseFareRules(paragraphs []RuleParagraph, query *domain.FareRulesQuery) *domain.FareRules {
+	rules := &domain.FareRules{
+		FareBasisCode:    query.FareBasisCode,
+		GoverningCarrier: query.GoverningCarrier,
+		BeginAirport:     query.BeginAirport,
+		EndAirport:       query.EndAirport,
+		Categories:       []domain.RuleCategory{},
+	}
+
+	for _, p := range paragraphs {
+		number, _ := strconv.Atoi(strings.TrimSpace(p.RPH))
+		text := strings.Join(p.Text, "\n")
+		rules.Categories = append(rules.Categories, domain.RuleCategory{Number: number, Title: p.Title, Text: text})
+
+		upper := strings.ToUpper(text)
+		switch number {
+		case domain.RuleCategoryAdvancePurchase:
+			rules.AdvancePurchase = parseAdvancePurchase(upper)
+		case domain.RuleCategoryMinimumStay:
+			rules.MinimumStay = parseStay(upper, "NO MINIMUM STAY")
+		case domain.RuleCategoryMaximumStay:
+			rules.MaximumStay = parseStay(upper, "NO MAXIMUM STAY")
+		case domain.RuleCategoryPenalties:
+			rules.Penalties = parsePenalties(upper)
+		}
+	}
+	return rules
+}
+
+// parsePenalties reads the change and cancellation conditions of category 16
+func parsePenalties(text string) *domain.PenaltyRules {
+	changes, cancellations := splitPenaltySections(text)
+	return &domain.PenaltyRules{
+		Change: parsePenalty(changes, []string{"CHANGES NOT PERMITTED", "CHANGE NOT PERMITTED", "NO CHANGES"}),
+		Cancel: parsePenalty(cancellations, []string{"NON-REFUNDABLE", "NONREFUNDABLE", "CANCELLATIONS NOT PERMITTED", "REFUND NOT PERMITTED"}),
+	}
+}
+
+// splitPenaltySections separates the CHANGES and CANCELLATIONS parts of category 16
+func splitPenaltySections(text string) (string, string) {
+	changeAt := strings.Index(text, "CHANGES")
+	cancelAt := strings.Index(text, "CANCELLATIONS")
+	switch {
+	case changeAt < 0 && cancelAt < 0:
+		return text, text
+	case changeAt < 0:
+		return "", text[cancelAt:]
+	case cancelAt < 0:
+		return text[changeAt:], ""
+	case changeAt < cancelAt:
+		return text[changeAt:cancelAt], text[cancelAt:]
+	default:
+		return text[changeAt:], text[cancelAt:changeAt]
+	}
+}
+
+// parsePenalty reads whether one action is permitted and its fee
+func parsePenalty(text string, forbidden []string) *domain.Penalty {
+	if text == "" {
+		return nil
+	}
+	for _, phrase := range forbidden {
+		if strings.Contains(text, phrase) {
+			return &domain.Penalty{Allowed: false}
+		}
+	}
+	penalty := &domain.Penalty{Allowed: true}
+	if m := chargePattern.FindStringSubmatch(text); m != nil {
+		amount, _ := strconv.ParseFloat(m[2], 64)
+		penalty.Fee = &domain.Money{Amount: amount, Currency: m[1]}
+	}
+	return penalty
+}
+
+// parseStay reads a minimum or maximum stay category
+func parseStay(text, unrestricted string) *domain.StayRule {
+	if strings.Contains(text, unrestricted) {
+		return &domain.StayRule{Restricted: false}
+	}
+	stay := &domain.StayRule{
+		Restricted:    true,
+		SaturdayNight: strings.Contains(text, "SATURDAY NIGHT") || strings.Contains(text, "SAT NIGHT"),
+	}
+	if m := monthsPattern.FindStringSubmatch(text); m != nil {
+		stay.Months, _ = strconv.Atoi(m[1])
+	}
+	if m := daysPattern.FindStringSubmatch(text); m != nil {
+		stay.Days, _ = strconv.Atoi(m[1])
+	}
+	return stay
+}
+
+// parseAdvancePurchase reads the advance reservation and ticketing category
+func parseAdvancePurchase(text string) *domain.AdvancePurchase {
+	ap := &domain.AdvancePurchase{}
+	if m := reservationsPattern.FindStringSubmatch(text); m != nil {
+		ap.ReservationDays, _ = strconv.Atoi(m[1])
+	}
+	if m := ticketingPattern.FindStringSubmatch(text); m != nil {
+		ap.TicketingDays, _ = strconv.Atoi(m[1])
+	}
+	ap.Restricted = ap.ReservationDays > 0 || ap.TicketingDays > 0
+	return ap
+}
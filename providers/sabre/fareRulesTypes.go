@@ -0,0 +1,72 @@
+package sabre
+
+// AirRulesRequestFormat wraps the Air Fare Rules request
+type AirRulesRequestFormat struct {
+	OTA_AirRulesRQ OTA_AirRulesRQ `json:"OTA_AirRulesRQ"`
+}
+
+// OTA_AirRulesRQ requests the rules of one fare component
+type OTA_AirRulesRQ struct {
+	Version                      string                 `json:"Version"`
+	OriginDestinationInformation RulesOriginDestination `json:"OriginDestinationInformation"`
+	RuleReqInfo                  RuleReqInfo            `json:"RuleReqInfo"`
+}
+
+// RulesOriginDestination is the market of the fare component
+type RulesOriginDestination struct {
+	FlightSegment RulesFlightSegment `json:"FlightSegment"`
+}
+
+// RulesFlightSegment is the market and travel date of the fare component
+type RulesFlightSegment struct {
+	DepartureDateTime   string       `json:"DepartureDateTime"` // Format: MM-DD
+	OriginLocation      LocationCode `json:"OriginLocation"`
+	DestinationLocation LocationCode `json:"DestinationLocation"`
+	MarketingCarrier    AirlineCode  `json:"MarketingCarrier"`
+}
+
+// RuleReqInfo identifies the fare and the categories to return
+type RuleReqInfo struct {
+	FareBasis  FareBasisCode   `json:"FareBasis"`
+	Category   []RuleCategoryN `json:"Category,omitempty"`
+	RuleTariff string          `json:"RuleTariff,omitempty"`
+	Rule       string          `json:"Rule,omitempty"`
+}
+
+// FareBasisCode is a fare basis code
+type FareBasisCode struct {
+	Code string `json:"Code"`
+}
+
+// RuleCategoryN is a rule category number
+type RuleCategoryN struct {
+	Value string `json:"value"`
+}
+
+// AirRulesResponseFormat wraps the Air Fare Rules response
+type AirRulesResponseFormat struct {
+	OTA_AirRulesRS OTA_AirRulesRS `json:"OTA_AirRulesRS"`
+}
+
+// OTA_AirRulesRS holds the rule text of a fare component
+type OTA_AirRulesRS struct {
+	ApplicationResults ApplicationResults `json:"ApplicationResults"`
+	FareRuleInfo       FareRuleInfo       `json:"FareRuleInfo"`
+}
+
+// FareRuleInfo holds the rule paragraphs
+type FareRuleInfo struct {
+	Rules RulesParagraphs `json:"Rules"`
+}
+
+// RulesParagraphs lists the rule paragraphs
+type RulesParagraphs struct {
+	Paragraph []RuleParagraph `json:"Paragraph"`
+}
+
+// RuleParagraph is the text of one rule category
+type RuleParagraph struct {
+	RPH   string   `json:"RPH"` // Category number
+	Title string   `json:"Title"`
+	Text  []string `json:"Text"`
+}
@@ -0,0 +1,152 @@
+package sabre
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// Severities of Sabre response messages
+const (
+	SeverityError   = "Error"
+	SeverityWarning = "Warning"
+	SeverityInfo    = "Info"
+)
+
+// messageIgnored marks messages that only carry diagnostics, such as the
+// transaction ID or the number of workers that served the search
+const messageIgnored = "ignored"
+
+// messageRule is the warning category of a Sabre message
+type messageRule struct {
+	Category    string
+	Description string // Shown instead of Sabre's text; empty keeps the text
+}
+
+// processingFailed describes errors raised by Sabre or the airline host rather than the search
+var processingFailed = messageRule{
+	Category:    domain.MessageProcessing,
+	Description: "an error occurred while searching for flights; please try again later",
+}
+
+// messageCodes maps Sabre message codes to their category
+// It is consulted first. Sabre reports journey and schedule problems under
+// generic codes with a free-text explanation, so those fall back to messageTexts.
+var messageCodes = map[string]messageRule{
+	// Diagnostics
+	"TRANSACTIONID":      {Category: messageIgnored},
+	"RULESCONTAINERGUID": {Category: messageIgnored},
+
+	// The request failed Sabre's validation; its text names the faulty field
+	"ERR.SWS.CLIENT.VALIDATION_FAILED": {Category: domain.MessageRequest},
+
+	// Sabre or the airline host failed
+	"ERR.SWS.HOST.ERROR_IN_RESPONSE":         processingFailed,
+	"ERR.SWS.PROVIDER.TIMEOUT":               processingFailed,
+	"ERR.SWS.PROVIDER.REQUEST_HANDLER_ERROR": processingFailed,
+}
+
+// messageTexts is the fallback for messages whose code is not in messageCodes
+// Prefixes are matched case-insensitively, in order.
+var messageTexts = []struct {
+	Prefix string
+	Rule   messageRule
+}{
+	{"No complete journey can be built", messageRule{
+		Category:    domain.MessageNoAvailability,
+		Description: "no flights available for the specified route and dates",
+	}},
+	{"Error during Processing", processingFailed},
+	{"NO FLIGHT SCHEDULES FOR QUALIFIERS USED", messageRule{
+		Category:    domain.MessageNoSchedules,
+		Description: "no flights found matching your search criteria (e.g., dates, route, or preferences)",
+	}},
+	{"NO FARES", messageRule{Category: domain.MessageNoAvailability}},
+	{"NO SEATS", messageRule{Category: domain.MessageNoAvailability}},
+	{"IGNORED", messageRule{Category: domain.MessageRequest}},
+	{"INVALID", messageRule{Category: domain.MessageRequest}},
+}
+
+// messageTypes classifies the remaining messages by their type
+// Worker messages carry a host name as their code, so they are told apart here.
+var messageTypes = map[string]messageRule{
+	"SERVER":    {Category: messageIgnored},
+	"WORKERS":   {Category: messageIgnored},
+	"SCHEDULES": {Category: domain.MessageNoSchedules},
+}
+
+// classifyMessage returns the rule of a message: by code, then text, then type
+// Errors no rule matches are processing errors; other messages are informational.
+func classifyMessage(msg Message) messageRule {
+	if rule, ok := messageCodes[strings.ToUpper(msg.Code)]; ok {
+		return rule
+	}
+	text := strings.ToUpper(strings.TrimSpace(msg.Text))
+	for _, t := range messageTexts {
+		if strings.HasPrefix(text, strings.ToUpper(t.Prefix)) {
+			return t.Rule
+		}
+	}
+	if rule, ok := messageTypes[strings.ToUpper(msg.Type)]; ok {
+		return rule
+	}
+	if strings.EqualFold(msg.Severity, SeverityError) {
+		return messageRule{Category: domain.MessageProcessing}
+	}
+	return messageRule{Category: domain.MessageInformation}
+}
+
+// messageText returns the text shown for a message under its rule
+func messageText(msg Message, rule messageRule) string {
+	if rule.Description != "" {
+		return rule.Description
+	}
+	return strings.TrimSpace(msg.Text)
+}
+
+// messagesError returns the error of the first Error message, which fails the search
+func messagesError(messages []Message) error {
+	for _, msg := range messages {
+		if !strings.EqualFold(msg.Severity, SeverityError) {
+			continue
+		}
+		if rule := classifyMessage(msg); rule.Description != "" {
+			return fmt.Errorf("%s", rule.Description)
+		}
+		return fmt.Errorf("sabre processing error: %s (%s)", strings.TrimSpace(msg.Text), msg.Code)
+	}
+	return nil
+}
+
+// messageWarnings normalizes Sabre's non-fatal messages into warnings, one per distinct message
+// Diagnostic messages are left out.
+func messageWarnings(messages []Message) []domain.Warning {
+	var warnings []domain.Warning
+	seen := make(map[Message]bool)
+	for _, msg := range messages {
+		rule := classifyMessage(msg)
+		if rule.Category == messageIgnored || seen[msg] {
+			continue
+		}
+		seen[msg] = true
+		warnings = append(warnings, domain.Warning{
+			Code:         domain.WarningProviderMessage,
+			Message:      messageText(msg, rule),
+			Category:     rule.Category,
+			ProviderCode: msg.Code,
+		})
+	}
+	return warnings
+}
+
+// noResultsError explains a search that returned no itineraries and no errors
+func noResultsError(messages []Message) error {
+	for _, msg := range messages {
+		rule := classifyMessage(msg)
+		if rule.Category == domain.MessageNoAvailability || rule.Category == domain.MessageNoSchedules {
+			return fmt.Errorf("%s", messageText(msg, rule))
+		}
+	}
+	return fmt.Errorf("no flights available for your search; try adjusting your dates or preferences")
+}
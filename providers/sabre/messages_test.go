@@ -0,0 +1,201 @@
+package sabre
+
+import (
+	"testing"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+func TestClassifyMessage(t *testing.T) {
+	tests := []struct {
+		name         string
+		msg          Message
+		wantCategory string
+		wantText     string
+	}{
+		{
+			name:         "code in the table",
+			msg:          Message{Severity: SeverityInfo, Type: "SERVER", Code: "TRANSACTIONID", Text: "1234"},
+			wantCategory: messageIgnored,
+			wantText:     "1234",
+		},
+		{
+			name:         "invalid request by code",
+			msg:          Message{Severity: SeverityError, Type: "DEFAULT", Code: "ERR.SWS.CLIENT.VALIDATION_FAILED", Text: "Request is invalid: OriginDestinationInformation"},
+			wantCategory: domain.MessageRequest,
+			wantText:     "Request is invalid: OriginDestinationInformation",
+		},
+		{
+			name:         "host failure by code",
+			msg:          Message{Severity: SeverityError, Type: "DEFAULT", Code: "ERR.SWS.HOST.ERROR_IN_RESPONSE", Text: "HOST ERROR 0012"},
+			wantCategory: domain.MessageProcessing,
+			wantText:     "an error occurred while searching for flights; please try again later",
+		},
+		{
+			name:         "timeout by code",
+			msg:          Message{Severity: SeverityError, Type: "DEFAULT", Code: "ERR.SWS.PROVIDER.TIMEOUT", Text: "Timeout"},
+			wantCategory: domain.MessageProcessing,
+			wantText:     "an error occurred while searching for flights; please try again later",
+		},
+		{
+			name:         "code wins over text",
+			msg:          Message{Severity: SeverityInfo, Type: "DEFAULT", Code: "RULESCONTAINERGUID", Text: "NO FARES"},
+			wantCategory: messageIgnored,
+			wantText:     "NO FARES",
+		},
+		{
+			name:         "text fallback for a generic code, ignoring case",
+			msg:          Message{Severity: SeverityWarning, Type: "DEFAULT", Code: "MSG", Text: "No fares for booking class J"},
+			wantCategory: domain.MessageNoAvailability,
+			wantText:     "No fares for booking class J",
+		},
+		{
+			name:         "text with a description",
+			msg:          Message{Severity: SeverityError, Type: "DEFAULT", Code: "ERR.SWS.PROVIDER", Text: "No complete journey can be built in IF2/ADVJR1."},
+			wantCategory: domain.MessageNoAvailability,
+			wantText:     "no flights available for the specified route and dates",
+		},
+		{
+			name:         "type fallback",
+			msg:          Message{Severity: SeverityInfo, Type: "SCHEDULES", Code: "MSG", Text: "ONLY 3 SCHEDULES FOUND"},
+			wantCategory: domain.MessageNoSchedules,
+			wantText:     "ONLY 3 SCHEDULES FOUND",
+		},
+		{
+			name:         "worker host name",
+			msg:          Message{Severity: SeverityInfo, Type: "WORKERS", Code: "ASE032LPS0CDB9C", Text: "28"},
+			wantCategory: messageIgnored,
+			wantText:     "28",
+		},
+		{
+			name:         "unmatched error",
+			msg:          Message{Severity: SeverityError, Type: "DEFAULT", Code: "ERR.SWS.HOST", Text: "HOST TIMEOUT"},
+			wantCategory: domain.MessageProcessing,
+			wantText:     "HOST TIMEOUT",
+		},
+		{
+			name:         "unmatched warning",
+			msg:          Message{Severity: SeverityWarning, Type: "DEFAULT", Code: "MSG", Text: "CABIN DOWNGRADED ON ONE SEGMENT"},
+			wantCategory: domain.MessageInformation,
+			wantText:     "CABIN DOWNGRADED ON ONE SEGMENT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := classifyMessage(tt.msg)
+			if rule.Category != tt.wantCategory {
+				t.Errorf("category = %q, want %q", rule.Category, tt.wantCategory)
+			}
+			if got := messageText(tt.msg, rule); got != tt.wantText {
+				t.Errorf("text = %q, want %q", got, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestMessageWarnings(t *testing.T) {
+	noFares := Message{Severity: SeverityWarning, Type: "DEFAULT", Code: "MSG", Text: "NO FARES FOR CARRIER XX"}
+	messages := []Message{
+		{Severity: SeverityInfo, Type: "SERVER", Code: "TRANSACTIONID", Text: "1234"},
+		noFares,
+		{Severity: SeverityInfo, Type: "WORKERS", Code: "ASE032LPS0CDB9C", Text: "28"},
+		noFares,
+		{Severity: SeverityInfo, Type: "SCHEDULES", Code: "MSG", Text: "NO FLIGHT SCHEDULES FOR QUALIFIERS USED"},
+	}
+
+	got := messageWarnings(messages)
+	want := []domain.Warning{
+		{Code: domain.WarningProviderMessage, Message: "NO FARES FOR CARRIER XX", Category: domain.MessageNoAvailability, ProviderCode: "MSG"},
+		{
+			Code:         domain.WarningProviderMessage,
+			Message:      "no flights found matching your search criteria (e.g., dates, route, or preferences)",
+			Category:     domain.MessageNoSchedules,
+			ProviderCode: "MSG",
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d warnings, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("warning %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMessagesError(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []Message
+		want     string
+	}{
+		{
+			name:     "no errors",
+			messages: []Message{{Severity: SeverityWarning, Type: "DEFAULT", Code: "MSG", Text: "NO FARES"}},
+		},
+		{
+			name:     "error with a description",
+			messages: []Message{{Severity: SeverityError, Type: "DEFAULT", Code: "ERR.SWS.PROVIDER", Text: "Error during Processing"}},
+			want:     "an error occurred while searching for flights; please try again later",
+		},
+		{
+			name: "first error wins",
+			messages: []Message{
+				{Severity: SeverityInfo, Type: "SERVER", Code: "TRANSACTIONID", Text: "1234"},
+				{Severity: SeverityError, Type: "DEFAULT", Code: "ERR.SWS.HOST", Text: " HOST TIMEOUT "},
+				{Severity: SeverityError, Type: "DEFAULT", Code: "ERR.SWS.PROVIDER", Text: "Error during Processing"},
+			},
+			want: "sabre processing error: HOST TIMEOUT (ERR.SWS.HOST)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := messagesError(tt.messages)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoResultsError(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []Message
+		want     string
+	}{
+		{
+			name: "no messages",
+			want: "no flights available for your search; try adjusting your dates or preferences",
+		},
+		{
+			name: "schedules explain the empty search",
+			messages: []Message{
+				{Severity: SeverityInfo, Type: "SERVER", Code: "TRANSACTIONID", Text: "1234"},
+				{Severity: SeverityInfo, Type: "SCHEDULES", Code: "MSG", Text: "NO FLIGHT SCHEDULES FOR QUALIFIERS USED"},
+			},
+			want: "no flights found matching your search criteria (e.g., dates, route, or preferences)",
+		},
+		{
+			name:     "availability keeps Sabre's text",
+			messages: []Message{{Severity: SeverityWarning, Type: "DEFAULT", Code: "MSG", Text: "NO SEATS IN REQUESTED CABIN"}},
+			want:     "NO SEATS IN REQUESTED CABIN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := noResultsError(tt.messages); err == nil || err.Error() != tt.want {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
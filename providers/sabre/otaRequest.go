@@ -0,0 +1,160 @@
+package sabre
+
+type RequestLocation struct {
+	LocationCode string `json:"LocationCode"`
+	LocationType string `json:"LocationType"`
+}
+
+type OriginDest struct {
+	RPH                 string                `json:"RPH,omitempty"`
+	OriginLocation      RequestLocation       `json:"OriginLocation"`
+	DestinationLocation RequestLocation       `json:"DestinationLocation"`
+	DepartureDateTime   string                `json:"DepartureDateTime"`
+	DepartureWindow     string                `json:"DepartureWindow,omitempty"` // Format: HHMMHHMM
+	ArrivalWindow       string                `json:"ArrivalWindow,omitempty"`   // Format: HHMMHHMM
+	TPA_Extensions      *OriginDestExtensions `json:"TPA_Extensions,omitempty"`
+}
+
+// OriginDestExtensions pins a leg to specific flights, as used by revalidation,
+// or widens it to additional airports
+type OriginDestExtensions struct {
+	Flight                    []RequestFlight  `json:"Flight,omitempty"`
+	SisterOriginLocation      []SisterLocation `json:"SisterOriginLocation,omitempty"`
+	SisterDestinationLocation []SisterLocation `json:"SisterDestinationLocation,omitempty"`
+	ConnectionTime            *ConnectionTime  `json:"ConnectionTime,omitempty"`
+}
+
+// ConnectionTime limits the connections of a leg, in minutes
+type ConnectionTime struct {
+	Min int `json:"Min,omitempty"`
+	Max int `json:"Max,omitempty"`
+}
+
+// SisterLocation is an alternate airport searched alongside the main location
+type SisterLocation struct {
+	LocationCode string `json:"LocationCode"`
+}
+
+// RequestFlight is a specific flight and booking class
+type RequestFlight struct {
+	Number              int             `json:"Number"`
+	DepartureDateTime   string          `json:"DepartureDateTime"`
+	ArrivalDateTime     string          `json:"ArrivalDateTime"`
+	Type                string          `json:"Type"`
+	ClassOfService      string          `json:"ClassOfService"`
+	OriginLocation      RequestLocation `json:"OriginLocation"`
+	DestinationLocation RequestLocation `json:"DestinationLocation"`
+	Airline             RequestAirline  `json:"Airline"`
+}
+
+// RequestAirline holds the marketing and operating carrier of a flight
+type RequestAirline struct {
+	Marketing string `json:"Marketing"`
+	Operating string `json:"Operating,omitempty"`
+}
+
+type OTA_AirLowFareSearchRQ struct {
+	Version                      string              `json:"Version"`
+	POS                          POS                 `json:"POS"`
+	OriginDestinationInformation []OriginDest        `json:"OriginDestinationInformation"`
+	TravelPreferences            TravelPreferences   `json:"TravelPreferences"`
+	TravelerInfoSummary          TravelerInfoSummary `json:"TravelerInfoSummary"`
+	TPA_Extensions               TPAExtensions       `json:"TPA_Extensions"`
+}
+
+type TravelPreferences struct {
+	Baggage        Baggage                      `json:"Baggage"`
+	TPA_Extensions *TravelPreferencesExtensions `json:"TPA_Extensions,omitempty"`
+}
+
+// TravelPreferencesExtensions holds Sabre specific travel preferences
+type TravelPreferencesExtensions struct {
+	VerificationItinCallLogic *VerificationItinCallLogic `json:"VerificationItinCallLogic,omitempty"`
+}
+
+// VerificationItinCallLogic controls how revalidation prices the given classes
+type VerificationItinCallLogic struct {
+	Value string `json:"Value"`
+}
+
+type Baggage struct {
+	CarryOnInfo bool   `json:"CarryOnInfo"`
+	Description bool   `json:"Description"`
+	RequestType string `json:"RequestType"`
+}
+type SabreRequestFormat struct {
+	OTA_AirLowFareSearchRQ OTA_AirLowFareSearchRQ `json:"OTA_AirLowFareSearchRQ"`
+}
+type POS struct {
+	Source []Source `json:"Source"`
+}
+
+type Source struct {
+	PseudoCityCode string      `json:"PseudoCityCode"`
+	RequestorID    RequestorID `json:"RequestorID"`
+}
+
+type RequestorID struct {
+	Type        string      `json:"Type"`
+	ID          string      `json:"ID"`
+	CompanyName CompanyName `json:"CompanyName"`
+}
+
+type CompanyName struct {
+	Code string `json:"Code"`
+}
+
+// OriginDestinationInformation related structs
+type OriginDestinationInfo struct {
+	DepartureDateTime   string          `json:"DepartureDateTime"`
+	OriginLocation      RequestLocation `json:"OriginLocation"`
+	DestinationLocation RequestLocation `json:"DestinationLocation"`
+}
+
+type VendorPref struct {
+	Code string `json:"Code"`
+}
+
+// TravelerInfoSummary related structs
+type TravelerInfoSummary struct {
+	AirTravelerAvail        []AirTravelerAvail             `json:"AirTravelerAvail"`
+	PriceRequestInformation *SearchPriceRequestInformation `json:"PriceRequestInformation,omitempty"`
+}
+
+// SearchPriceRequestInformation holds the pricing options of a search
+type SearchPriceRequestInformation struct {
+	TPA_Extensions PriceRequestExtensions `json:"TPA_Extensions"`
+}
+
+// PriceRequestExtensions holds the branded fare options of a search
+type PriceRequestExtensions struct {
+	BrandedFareIndicators BrandedFareIndicators `json:"BrandedFareIndicators"`
+}
+
+// BrandedFareIndicators asks for every fare family of each itinerary
+type BrandedFareIndicators struct {
+	MultipleBrandedFares   bool `json:"MultipleBrandedFares"`
+	ReturnBrandAncillaries bool `json:"ReturnBrandAncillaries"`
+}
+
+type AirTravelerAvail struct {
+	PassengerTypeQuantity []PassengerTypeQuantity `json:"PassengerTypeQuantity"`
+}
+
+type PassengerTypeQuantity struct {
+	Code     string `json:"Code"`
+	Quantity int    `json:"Quantity"`
+}
+
+// TPA_Extensions related structs
+type TPAExtensions struct {
+	IntelliSellTransaction IntelliSellTransaction `json:"IntelliSellTransaction"`
+}
+
+type IntelliSellTransaction struct {
+	RequestType RequestType `json:"RequestType"`
+}
+
+type RequestType struct {
+	Name string `json:"Name"`
+}
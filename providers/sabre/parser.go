@@ -0,0 +1,530 @@
+package sabre
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// ParseSabreResponse converts Sabre's API response into provider-neutral offers
+// Itineraries that cannot be parsed are skipped and reported in the result
+// warnings instead of failing the whole search.
+// Args:
+//
+//	resp - The raw response from Sabre API
+//	airportTimes - Airport time zones; nil leaves only the local clock times
+//
+// Returns:
+//
+//	Pointer to SearchResult containing parsed offers and any error encountered
+func ParseSabreResponse(resp SabreResponse, airportTimes interfaces.AirportTimes) (*domain.SearchResult, error) {
+	// Sabre's non-fatal messages come first, ahead of the warnings raised while parsing
+	result := &domain.SearchResult{Warnings: messageWarnings(resp.GroupedItineraryResponse.Messages)}
+
+	// Precompute mappings with capacity hints
+	baggageMap := make(map[int]BaggageAllowanceType, len(resp.GroupedItineraryResponse.BaggageAllowanceDescs))
+	for _, allowance := range resp.GroupedItineraryResponse.BaggageAllowanceDescs {
+		baggageMap[allowance.ID] = allowance
+	}
+
+	fareComponentsMap := make(map[int]FareComponentType, len(resp.GroupedItineraryResponse.FareComponentDescs))
+	for _, fare := range resp.GroupedItineraryResponse.FareComponentDescs {
+		fareComponentsMap[fare.ID] = fare
+	}
+
+	baggageChargeMap := make(map[int]BaggageChargeType, len(resp.GroupedItineraryResponse.BaggageChargeDescs))
+	for _, charge := range resp.GroupedItineraryResponse.BaggageChargeDescs {
+		baggageChargeMap[charge.ID] = charge
+	}
+
+	scheduleMap := make(map[int]ScheduleDesc, len(resp.GroupedItineraryResponse.ScheduleDescs))
+	for _, sched := range resp.GroupedItineraryResponse.ScheduleDescs {
+		scheduleMap[sched.ID] = sched
+	}
+
+	taxMap := make(map[int]TaxDesc, len(resp.GroupedItineraryResponse.TaxDescs))
+	for _, tax := range resp.GroupedItineraryResponse.TaxDescs {
+		taxMap[tax.ID] = tax
+	}
+
+	taxSummaryMap := make(map[int]TaxSummaryDesc, len(resp.GroupedItineraryResponse.TaxSummaryDescs))
+	for _, summary := range resp.GroupedItineraryResponse.TaxSummaryDescs {
+		taxSummaryMap[summary.ID] = summary
+	}
+
+	legMap := make(map[int]LegDesc, len(resp.GroupedItineraryResponse.LegDescs))
+	for _, leg := range resp.GroupedItineraryResponse.LegDescs {
+		legMap[leg.ID] = leg
+	}
+
+	brandFeatureMap := make(map[int]BrandFeatureDesc, len(resp.GroupedItineraryResponse.BrandFeatureDescs))
+	for _, feature := range resp.GroupedItineraryResponse.BrandFeatureDescs {
+		brandFeatureMap[feature.ID] = feature
+	}
+
+	descs := responseDescs{
+		baggage:        baggageMap,
+		baggageCharges: baggageChargeMap,
+		schedules:      scheduleMap,
+		taxes:          taxMap,
+		taxSummaries:   taxSummaryMap,
+		legs:           legMap,
+		fares:          fareComponentsMap,
+		brandFeatures:  brandFeatureMap,
+	}
+
+	// Process itineraries, skipping the ones Sabre returned in an unexpected shape
+	for _, group := range resp.GroupedItineraryResponse.ItineraryGroups {
+		for _, itin := range group.Itineraries {
+			offers, warnings, err := processItinerary(itin, group, descs, airportTimes)
+			if err != nil {
+				result.Warnings = append(result.Warnings, domain.Warning{
+					Code:    domain.WarningItinerarySkipped,
+					Message: err.Error(),
+					OfferID: strconv.Itoa(itin.ID),
+				})
+				continue
+			}
+			result.Warnings = append(result.Warnings, warnings...)
+			for i := range offers {
+				if warning := priceMismatchWarning(&offers[i]); warning != nil {
+					result.Warnings = append(result.Warnings, *warning)
+				}
+			}
+			result.Offers = append(result.Offers, offers...)
+		}
+	}
+
+	// Sort offers by price
+	sort.SliceStable(result.Offers, func(i, j int) bool {
+		return result.Offers[i].Price.Total < result.Offers[j].Price.Total
+	})
+
+	return result, nil
+}
+
+// responseDescs holds the descriptor lookups shared by every itinerary in a response
+type responseDescs struct {
+	baggage        map[int]BaggageAllowanceType
+	baggageCharges map[int]BaggageChargeType
+	schedules      map[int]ScheduleDesc
+	taxes          map[int]TaxDesc
+	taxSummaries   map[int]TaxSummaryDesc
+	legs           map[int]LegDesc
+	fares          map[int]FareComponentType
+	brandFeatures  map[int]BrandFeatureDesc
+}
+
+// segmentPassengerKey identifies a segment for a given passenger in the itinerary
+type segmentPassengerKey struct {
+	Segment   int
+	Passenger int
+}
+
+// processItinerary converts a single itinerary into one offer per priced option.
+// When every option is a fare brand they are folded into a single offer listing
+// the brands as fare families. Nothing is returned when an error is returned, so
+// a malformed itinerary never leaves partial results behind. Flight times that
+// cannot be placed on the calendar are reported as warnings instead.
+func processItinerary(itin Itinerary, group ItineraryGroup, descs responseDescs, airportTimes interfaces.AirportTimes) (offers []domain.Offer, warnings []domain.Warning, err error) {
+	// Last line of defence: a shape we did not anticipate must not take the handler down
+	defer func() {
+		if r := recover(); r != nil {
+			offers, warnings = nil, nil
+			err = fmt.Errorf("unexpected itinerary structure: %v", r)
+		}
+	}()
+
+	if len(itin.Legs) > len(group.GroupDescription.LegDescriptions) {
+		return nil, nil, fmt.Errorf("itinerary has %d legs but its group describes %d", len(itin.Legs), len(group.GroupDescription.LegDescriptions))
+	}
+
+	offers = make([]domain.Offer, 0, len(itin.PricingInformation))
+	families := make([]domain.FareFamily, 0, len(itin.PricingInformation))
+	for pricingIdx, pricing := range itin.PricingInformation {
+		if len(pricing.Fare.PassengerInfoList) == 0 {
+			continue
+		}
+
+		// Build itinerary-wide baggage info
+		baggageInfo := make(map[segmentPassengerKey][]domain.BaggageAllowance)
+		chargeInfo := make(map[segmentPassengerKey][]domain.BaggageCharge)
+		for idx, passenger := range pricing.Fare.PassengerInfoList {
+			for _, bag := range passenger.PassengerInfo.BaggageInformation {
+				switch bag.ProvisionType {
+				case ProvisionCheckedCharges, ProvisionCarryOnCharges:
+					if bag.Charge == nil {
+						return nil, nil, fmt.Errorf("baggage charge for passenger %d has no reference", idx)
+					}
+					if charge, ok := descs.baggageCharges[bag.Charge.Ref]; ok {
+						parsed := baggageChargeFromSabre(charge)
+						parsed.CarryOn = bag.ProvisionType == ProvisionCarryOnCharges
+						for _, seg := range bag.Segments {
+							key := segmentPassengerKey{Segment: seg.ID, Passenger: idx}
+							chargeInfo[key] = append(chargeInfo[key], parsed)
+						}
+					}
+				case ProvisionCheckedAllowance, ProvisionCarryOnAllowance:
+					if bag.Allowance == nil {
+						return nil, nil, fmt.Errorf("baggage allowance for passenger %d has no reference", idx)
+					}
+					if allowance, ok := descs.baggage[bag.Allowance.Ref]; ok {
+						parsed := baggageAllowanceFromSabre(allowance)
+						parsed.CarryOn = bag.ProvisionType == ProvisionCarryOnAllowance
+						for _, seg := range bag.Segments {
+							key := segmentPassengerKey{Segment: seg.ID, Passenger: idx}
+							baggageInfo[key] = append(baggageInfo[key], parsed)
+						}
+					}
+				}
+				// Embargoes and other provisions carry no allowance or price
+			}
+		}
+
+		// Booking class, cabin, meal and seats come with the fare components in
+		// itinerary order; the first passenger type describes the segment
+		var segmentDetails []SegmentDetails
+		for _, component := range pricing.Fare.PassengerInfoList[0].PassengerInfo.FareComponents {
+			for _, seg := range component.Segments {
+				segmentDetails = append(segmentDetails, seg.Segment)
+			}
+		}
+
+		globalSegIdx := 0
+		legs := make([]domain.Leg, 0, len(itin.Legs))
+
+		for i, legRef := range itin.Legs {
+			legDesc, ok := descs.legs[legRef.Ref]
+			if !ok {
+				return nil, nil, fmt.Errorf("leg reference %d not found in legDescs", legRef.Ref)
+			}
+
+			legDescription := group.GroupDescription.LegDescriptions[i]
+			segments := make([]domain.Segment, 0, len(legDesc.Schedules))
+			legDate, err := time.Parse("2006-01-02", legDescription.DepartureDate)
+			if err != nil {
+				return nil, nil, fmt.Errorf("leg %d has invalid departure date %q", i, legDescription.DepartureDate)
+			}
+
+			// City and multi-airport searches describe the leg with the requested codes;
+			// the airports actually flown come from its first and last flights
+			var legEnds legAirports
+			if len(legDesc.Schedules) > 0 {
+				first, okFirst := descs.schedules[legDesc.Schedules[0].Ref]
+				last, okLast := descs.schedules[legDesc.Schedules[len(legDesc.Schedules)-1].Ref]
+				if okFirst && okLast {
+					legEnds = legAirports{Origin: first.Departure.Airport, Destination: last.Arrival.Airport}
+				}
+			}
+
+			for _, schedRef := range legDesc.Schedules {
+				flightData, ok := descs.schedules[schedRef.Ref]
+				if !ok {
+					return nil, nil, fmt.Errorf("schedule reference %d not found in scheduleDescs", schedRef.Ref)
+				}
+
+				passengers := make([]domain.SegmentPassenger, len(pricing.Fare.PassengerInfoList))
+				for idx, passenger := range pricing.Fare.PassengerInfoList {
+					key := segmentPassengerKey{Segment: globalSegIdx, Passenger: idx}
+					passengers[idx] = domain.SegmentPassenger{
+						PassengerType:   passenger.PassengerInfo.PassengerType,
+						PassengerNumber: passenger.PassengerInfo.PassengerNumber,
+						NonRefundable:   passenger.PassengerInfo.NonRefundable,
+						Baggage:         baggageInfo[key],
+						BaggageCharges:  chargeInfo[key],
+						FareComponent:   segmentFareComponent(passenger.PassengerInfo.FareComponents, flightData, legEnds, descs.fares),
+					}
+				}
+
+				segment := segmentFromSabre(flightData, passengers)
+				if globalSegIdx < len(segmentDetails) {
+					applySegmentDetails(&segment, segmentDetails[globalSegIdx])
+				}
+				if err := setSegmentTimes(&segment, flightData, legDate, schedRef.DepartureDateAdjustment, airportTimes); err != nil {
+					warnings = append(warnings, domain.Warning{
+						Code:    domain.WarningSegmentTimesUnknown,
+						Message: err.Error(),
+						OfferID: strconv.Itoa(itin.ID),
+					})
+				}
+				segments = append(segments, segment)
+				globalSegIdx++
+			}
+			if len(segments) > 0 {
+				legs = append(legs, domain.Leg{
+					DepartureDate: legDescription.DepartureDate,
+					Origin:        legEnds.Origin,
+					Destination:   legEnds.Destination,
+					ElapsedTime:   legDesc.ElapsedTime,
+					Segments:      segments,
+				})
+			}
+		}
+		if len(legs) == 0 {
+			continue
+		}
+
+		offerID := strconv.Itoa(itin.ID)
+		if pricingIdx > 0 {
+			offerID += "-" + strconv.Itoa(pricingIdx)
+		}
+		offer := domain.Offer{
+			ID:                offerID,
+			Legs:              legs,
+			Price:             priceFromSabre(pricing.Fare.TotalFare),
+			ValidatingCarrier: pricing.Fare.ValidatingCarrierCode,
+			LastTicketDate:    pricing.Fare.LastTicketDate,
+		}
+		offer.ComputeDurations()
+		offer.ComputeBaggagePolicies()
+		for _, passenger := range pricing.Fare.PassengerInfoList {
+			fare, err := passengerFareFromSabre(passenger.PassengerInfo, descs)
+			if err != nil {
+				return nil, nil, err
+			}
+			offer.PassengerFares = append(offer.PassengerFares, fare)
+		}
+		offers = append(offers, offer)
+		if family := fareFamilyFromSabre(pricing, offer, baggageInfo[segmentPassengerKey{}], descs); family != nil {
+			families = append(families, *family)
+		}
+	}
+
+	if len(families) > 0 && len(families) == len(offers) {
+		return []domain.Offer{foldFareFamilies(offers, families)}, warnings, nil
+	}
+	return offers, warnings, nil
+}
+
+// legAirports are the airports a leg actually departs from and arrives at
+type legAirports struct {
+	Origin      string
+	Destination string
+}
+
+// segmentFareComponent finds the fare component covering a segment for one passenger.
+// A component matching the segment's own airports wins; otherwise a multi-segment
+// component spanning the whole leg is used.
+func segmentFareComponent(components []FareComponent, flightData ScheduleDesc, leg legAirports, fares map[int]FareComponentType) *domain.FareComponent {
+	for _, farecomp := range components {
+		if farecomp.BeginAirport == flightData.Departure.Airport && farecomp.EndAirport == flightData.Arrival.Airport {
+			return fareComponentFromSabre(fares[farecomp.Ref], farecomp)
+		}
+	}
+	for _, farecomp := range components {
+		if farecomp.BeginAirport == leg.Origin && farecomp.EndAirport == leg.Destination && len(farecomp.Segments) > 1 {
+			return fareComponentFromSabre(fares[farecomp.Ref], farecomp)
+		}
+	}
+	return nil
+}
+
+// segmentFromSabre maps a Sabre schedule onto a domain segment
+func segmentFromSabre(sched ScheduleDesc, passengers []domain.SegmentPassenger) domain.Segment {
+	return domain.Segment{
+		Departure:             endpointFromSabre(sched.Departure),
+		Arrival:               endpointFromSabre(sched.Arrival),
+		MarketingCarrier:      sched.Carrier.Marketing,
+		MarketingFlightNumber: sched.Carrier.MarketingFlightNumber,
+		OperatingCarrier:      sched.Carrier.Operating,
+		OperatingFlightNumber: sched.Carrier.OperatingFlightNumber,
+		Equipment:             sched.Carrier.Equipment.Code,
+		ElapsedTime:           sched.ElapsedTime,
+		StopCount:             sched.StopCount,
+		MilesFlown:            sched.TotalMilesFlown,
+		ETicketable:           sched.ETicketable,
+		Passengers:            passengers,
+	}
+}
+
+// applySegmentDetails copies the booking details Sabre reports with a fare component segment
+// Sabre leaves seatsAvailable out when it does not know it.
+func applySegmentDetails(segment *domain.Segment, details SegmentDetails) {
+	segment.BookingClass = details.BookingCode
+	segment.Cabin = details.CabinCode
+	segment.MealCode = details.MealCode
+	segment.Meal = domain.MealService(details.MealCode)
+	if details.SeatsAvailable > 0 {
+		seats := details.SeatsAvailable
+		segment.SeatsAvailable = &seats
+	}
+}
+
+// setSegmentTimes places a segment's departure and arrival on the calendar
+// Sabre reports clock times with their UTC offset; the dates follow from the leg's
+// departure date and the day adjustments of the schedule. An endpoint whose time
+// cannot be resolved keeps its local clock time and day offset only; the first
+// such failure is returned.
+func setSegmentTimes(segment *domain.Segment, sched ScheduleDesc, legDate time.Time, departureAdjustment int, airportTimes interfaces.AirportTimes) error {
+	segment.Departure.DayOffset = departureAdjustment
+	segment.Arrival.DayOffset = departureAdjustment + sched.Arrival.DateAdjustment
+	if airportTimes == nil {
+		return nil
+	}
+	departureDate := legDate.AddDate(0, 0, departureAdjustment)
+	var resolveErr error
+	departure, err := airportTimes.AirportTime(sched.Departure.Airport, departureDate.Format("2006-01-02"), sched.Departure.Time)
+	if err != nil {
+		resolveErr = fmt.Errorf("schedule %d departure: %v", sched.ID, err)
+	} else {
+		segment.Departure.SetDateTime(departure, legDate)
+	}
+	arrivalDate := departureDate.AddDate(0, 0, sched.Arrival.DateAdjustment)
+	arrival, err := airportTimes.AirportTime(sched.Arrival.Airport, arrivalDate.Format("2006-01-02"), sched.Arrival.Time)
+	if err != nil {
+		if resolveErr == nil {
+			resolveErr = fmt.Errorf("schedule %d arrival: %v", sched.ID, err)
+		}
+	} else {
+		segment.Arrival.SetDateTime(arrival, legDate)
+	}
+	return resolveErr
+}
+
+// passengerFareFromSabre itemizes the fare of one passenger type
+// Tax summaries already total the taxes per code; the individual taxes are only
+// used when Sabre sent no summaries.
+func passengerFareFromSabre(info PassengerDetails, descs responseDescs) (domain.PassengerFare, error) {
+	total := info.PassengerTotalFare
+	fare := domain.PassengerFare{
+		PassengerType:  info.PassengerType,
+		PassengerCount: info.PassengerNumber,
+		Base:           total.BaseFareAmount,
+		TotalTaxes:     total.TotalTaxAmount,
+		Total:          total.TotalFare,
+		Currency:       total.Currency,
+		NonRefundable:  info.NonRefundable,
+	}
+	if total.EquivalentAmount != 0 {
+		fare.Base = total.EquivalentAmount
+	}
+	for _, component := range info.FareComponents {
+		if desc, ok := descs.fares[component.Ref]; ok && desc.FareBasisCode != nil {
+			fare.FareBasisCodes = append(fare.FareBasisCodes, *desc.FareBasisCode)
+		}
+	}
+
+	if len(info.TaxSummaries) > 0 {
+		for _, ref := range info.TaxSummaries {
+			summary, ok := descs.taxSummaries[ref.Ref]
+			if !ok {
+				return domain.PassengerFare{}, fmt.Errorf("tax summary reference %d not found in taxSummaryDescs", ref.Ref)
+			}
+			fare.Taxes = append(fare.Taxes, domain.Tax{
+				Code:        summary.Code,
+				Description: summary.Description,
+				Amount:      summary.Amount,
+				Currency:    summary.Currency,
+			})
+		}
+		return fare, nil
+	}
+	for _, ref := range info.Taxes {
+		tax, ok := descs.taxes[ref.Ref]
+		if !ok {
+			return domain.PassengerFare{}, fmt.Errorf("tax reference %d not found in taxDescs", ref.Ref)
+		}
+		fare.Taxes = append(fare.Taxes, domain.Tax{
+			Code:        tax.Code,
+			Description: tax.Description,
+			Amount:      tax.Amount,
+			Currency:    tax.Currency,
+		})
+	}
+	return fare, nil
+}
+
+// priceMismatchWarning flags an offer whose passenger fares do not add up to its total
+func priceMismatchWarning(offer *domain.Offer) *domain.Warning {
+	sum, mismatch := offer.PassengerFaresMismatch()
+	if !mismatch {
+		return nil
+	}
+	return &domain.Warning{
+		Code:    domain.WarningPriceMismatch,
+		Message: fmt.Sprintf("passenger fares add up to %.2f, total is %.2f %s", sum, offer.Price.Total, offer.Price.Currency),
+		OfferID: offer.ID,
+	}
+}
+
+func endpointFromSabre(loc Location) domain.Endpoint {
+	return domain.Endpoint{
+		Airport:  loc.Airport,
+		City:     loc.City,
+		Country:  loc.Country,
+		Terminal: loc.Terminal,
+		Time:     loc.Time,
+	}
+}
+
+// priceFromSabre uses the equivalent amount as base fare when Sabre priced in another currency
+func priceFromSabre(fare TotalFare) domain.Price {
+	base := fare.BaseFareAmount
+	if fare.EquivalentAmount != 0 {
+		base = fare.EquivalentAmount
+	}
+	return domain.Price{
+		Total:    fare.TotalPrice,
+		Base:     base,
+		Taxes:    fare.TotalTaxAmount,
+		Currency: fare.Currency,
+	}
+}
+
+func fareComponentFromSabre(desc FareComponentType, ref FareComponent) *domain.FareComponent {
+	return &domain.FareComponent{
+		FareBasisCode:    deref(desc.FareBasisCode),
+		GoverningCarrier: deref(desc.GoverningCarrier),
+		BeginAirport:     ref.BeginAirport,
+		EndAirport:       ref.EndAirport,
+		CabinCode:        deref(desc.CabinCode),
+		Amount:           deref(desc.FareAmount),
+		Currency:         deref(desc.FareCurrency),
+		FareRule:         deref(desc.FareRule),
+		FareTariff:       deref(desc.FareTariff),
+		VendorCode:       deref(desc.VendorCode),
+	}
+}
+
+func baggageAllowanceFromSabre(allowance BaggageAllowanceType) domain.BaggageAllowance {
+	return domain.BaggageAllowance{
+		Pieces:      allowance.PieceCount,
+		Weight:      allowance.Weight,
+		Unit:        deref(allowance.Unit),
+		Description: descriptions(allowance.Description1, allowance.Description2),
+	}
+}
+
+func baggageChargeFromSabre(charge BaggageChargeType) domain.BaggageCharge {
+	return domain.BaggageCharge{
+		Amount:      deref(charge.EquivalentAmount),
+		Currency:    deref(charge.EquivalentCurrency),
+		FirstPiece:  deref(charge.FirstPiece),
+		LastPiece:   deref(charge.LastPiece),
+		Description: descriptions(charge.Description1, charge.Description2),
+	}
+}
+
+// descriptions collects the non-empty free-text description lines
+func descriptions(lines ...*string) []string {
+	var out []string
+	for _, line := range lines {
+		if line != nil && *line != "" {
+			out = append(out, *line)
+		}
+	}
+	return out
+}
+
+// deref returns the value behind an optional Sabre field, or its zero value
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,197 @@
+package sabre
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// loadSabreResponse reads a recorded Sabre response from testdata
+func loadSabreResponse(t testing.TB, name string) SabreResponse {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading %s: %v", name, err)
+	}
+	var resp SabreResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		t.Fatalf("decoding %s: %v", name, err)
+	}
+	return resp
+}
+
+// stubAirportTimes places flight times by their UTC offsets, failing at one airport
+type stubAirportTimes struct {
+	unknown string // Airport without a known time zone
+}
+
+func (s stubAirportTimes) AirportTime(code, date, clock string) (time.Time, error) {
+	if code == s.unknown {
+		return time.Time{}, fmt.Errorf("no time zone known for %s", code)
+	}
+	return time.Parse(time.RFC3339, date+"T"+clock)
+}
+
+func TestParseSabreResponse(t *testing.T) {
+	resp := loadSabreResponse(t, "one_way.json")
+
+	got, err := ParseSabreResponse(resp, stubAirportTimes{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %+v", got.Warnings)
+	}
+	if len(got.Offers) != 2 {
+		t.Fatalf("expected 2 offers, got %d", len(got.Offers))
+	}
+	if got.Offers[0].Price.Total > got.Offers[1].Price.Total {
+		t.Errorf("flights not sorted by price: %v then %v", got.Offers[0].Price.Total, got.Offers[1].Price.Total)
+	}
+}
+
+func TestParseSabreResponseSkipsMalformedItineraries(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(resp *SabreResponse)
+	}{
+		{
+			name: "missing leg description",
+			mutate: func(resp *SabreResponse) {
+				resp.GroupedItineraryResponse.ItineraryGroups[0].Itineraries[1].Legs = []LegRef{{Ref: 2}, {Ref: 1}}
+			},
+		},
+		{
+			name: "unknown leg reference",
+			mutate: func(resp *SabreResponse) {
+				resp.GroupedItineraryResponse.ItineraryGroups[0].Itineraries[1].Legs[0].Ref = 99
+			},
+		},
+		{
+			name: "unknown schedule reference",
+			mutate: func(resp *SabreResponse) {
+				resp.GroupedItineraryResponse.LegDescs[1].Schedules[1].Ref = 99
+			},
+		},
+		{
+			name: "baggage charge without reference",
+			mutate: func(resp *SabreResponse) {
+				pax := &resp.GroupedItineraryResponse.ItineraryGroups[0].Itineraries[1].PricingInformation[0].Fare.PassengerInfoList[0]
+				pax.PassengerInfo.BaggageInformation[1].Charge = nil
+			},
+		},
+		{
+			name: "baggage allowance without reference",
+			mutate: func(resp *SabreResponse) {
+				pax := &resp.GroupedItineraryResponse.ItineraryGroups[0].Itineraries[1].PricingInformation[0].Fare.PassengerInfoList[0]
+				pax.PassengerInfo.BaggageInformation[0].Allowance = nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := loadSabreResponse(t, "one_way.json")
+			tt.mutate(&resp)
+
+			got, err := ParseSabreResponse(resp, stubAirportTimes{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got.Offers) != 1 {
+				t.Fatalf("expected the healthy itinerary to survive, got %d offers", len(got.Offers))
+			}
+			if len(got.Warnings) != 1 {
+				t.Fatalf("expected 1 warning, got %+v", got.Warnings)
+			}
+			if w := got.Warnings[0]; w.Code != domain.WarningItinerarySkipped || w.OfferID != "2" {
+				t.Errorf("unexpected warning: %+v", w)
+			}
+		})
+	}
+}
+
+func TestParseSabreResponseUnknownTimeZone(t *testing.T) {
+	resp := loadSabreResponse(t, "one_way.json")
+
+	got, err := ParseSabreResponse(resp, stubAirportTimes{unknown: "ORD"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Offers) != 2 {
+		t.Fatalf("expected both itineraries to survive, got %d offers", len(got.Offers))
+	}
+	var warnings []string
+	for _, w := range got.Warnings {
+		if w.Code != domain.WarningSegmentTimesUnknown || w.OfferID != "2" {
+			t.Errorf("unexpected warning: %+v", w)
+		}
+		warnings = append(warnings, w.Message)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected a warning per segment touching ORD, got %q", warnings)
+	}
+
+	for _, offer := range got.Offers {
+		for _, seg := range offer.Legs[0].Segments {
+			for _, endpoint := range []domain.Endpoint{seg.Departure, seg.Arrival} {
+				if endpoint.Airport == "ORD" {
+					if endpoint.LocalDateTime != "" || endpoint.UTCDateTime != "" {
+						t.Errorf("ORD times should be left empty, got %q and %q", endpoint.LocalDateTime, endpoint.UTCDateTime)
+					}
+					if endpoint.Time == "" {
+						t.Error("ORD clock time should be kept")
+					}
+				} else if endpoint.UTCDateTime == "" {
+					t.Errorf("%s time should be resolved", endpoint.Airport)
+				}
+			}
+		}
+	}
+}
+
+func FuzzParseSabreResponse(f *testing.F) {
+	seeds, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range seeds {
+		raw, err := os.ReadFile(seed)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(raw)
+	}
+	f.Add([]byte(`{"groupedItineraryResponse":{"itineraryGroups":[{"itineraries":[{"id":1,"legs":[{"ref":1}]}]}]}}`))
+	f.Add([]byte(`{"groupedItineraryResponse":{"itineraryGroups":[{"itineraries":[{"id":1,"pricingInformation":[{"fare":{"passengerInfoList":[{"passengerInfo":{"baggageInformation":[{"provisionType":"C"}]}}]}}]}]}]}}`))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		var resp SabreResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return
+		}
+
+		got, err := ParseSabreResponse(resp, stubAirportTimes{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, offer := range got.Offers {
+			if len(offer.Legs) == 0 {
+				t.Fatalf("offer %d has no legs", i)
+			}
+			if i > 0 && got.Offers[i-1].Price.Total > offer.Price.Total {
+				t.Fatalf("offers not sorted by price at %d", i)
+			}
+		}
+		for _, w := range got.Warnings {
+			if w.Code == "" || w.Message == "" {
+				t.Fatalf("incomplete warning: %+v", w)
+			}
+		}
+	})
+}
@@ -0,0 +1,281 @@
+package sabre
+
+// CreatePNRRequestFormat wraps the Create Passenger Name Record request
+type CreatePNRRequestFormat struct {
+	CreatePassengerNameRecordRQ CreatePassengerNameRecordRQ `json:"CreatePassengerNameRecordRQ"`
+}
+
+// CreatePassengerNameRecordRQ books, prices and stores a reservation in one call
+type CreatePassengerNameRecordRQ struct {
+	Version                string                 `json:"version"`
+	TargetCity             string                 `json:"targetCity"`
+	HaltOnAirPriceError    bool                   `json:"haltOnAirPriceError"`
+	TravelItineraryAddInfo TravelItineraryAddInfo `json:"TravelItineraryAddInfo"`
+	AirBook                AirBook                `json:"AirBook"`
+	AirPrice               []AirPriceRQ           `json:"AirPrice"`
+	SpecialReqDetails      *SpecialReqDetails     `json:"SpecialReqDetails,omitempty"`
+	PostProcessing         PostProcessing         `json:"PostProcessing"`
+}
+
+// TravelItineraryAddInfo holds agency and customer data
+type TravelItineraryAddInfo struct {
+	AgencyInfo   AgencyInfo   `json:"AgencyInfo"`
+	CustomerInfo CustomerInfo `json:"CustomerInfo"`
+}
+
+// AgencyInfo holds the ticketing arrangement
+type AgencyInfo struct {
+	Ticketing TicketingArrangement `json:"Ticketing"`
+}
+
+// TicketingArrangement sets the ticketing time limit field of the PNR
+type TicketingArrangement struct {
+	TicketType string `json:"TicketType"`
+}
+
+// CustomerInfo holds names and contact details
+type CustomerInfo struct {
+	ContactNumbers ContactNumbers `json:"ContactNumbers"`
+	Email          []EmailAddress `json:"Email,omitempty"`
+	PersonName     []PersonName   `json:"PersonName"`
+}
+
+// ContactNumbers lists phone numbers
+type ContactNumbers struct {
+	ContactNumber []ContactNumber `json:"ContactNumber"`
+}
+
+// ContactNumber is a single phone number
+type ContactNumber struct {
+	NameNumber   string `json:"NameNumber,omitempty"`
+	Phone        string `json:"Phone"`
+	PhoneUseType string `json:"PhoneUseType"`
+}
+
+// EmailAddress is a single e-mail address
+type EmailAddress struct {
+	Address    string `json:"Address"`
+	NameNumber string `json:"NameNumber,omitempty"`
+	Type       string `json:"Type"`
+}
+
+// PersonName is a passenger name field
+type PersonName struct {
+	NameNumber    string `json:"NameNumber"`
+	NameReference string `json:"NameReference,omitempty"`
+	PassengerType string `json:"PassengerType"`
+	GivenName     string `json:"GivenName"`
+	Surname       string `json:"Surname"`
+	Infant        bool   `json:"Infant,omitempty"`
+}
+
+// AirBook sells the flight segments
+type AirBook struct {
+	HaltOnStatus                 []HaltOnStatus              `json:"HaltOnStatus"`
+	OriginDestinationInformation BookOriginDestinationInfo   `json:"OriginDestinationInformation"`
+	RedisplayReservation         AirBookRedisplayReservation `json:"RedisplayReservation"`
+}
+
+// HaltOnStatus stops the transaction when a segment comes back with the status
+type HaltOnStatus struct {
+	Code string `json:"Code"`
+}
+
+// BookOriginDestinationInfo lists the segments to sell
+type BookOriginDestinationInfo struct {
+	FlightSegment []BookFlightSegment `json:"FlightSegment"`
+}
+
+// BookFlightSegment is a single segment to sell
+type BookFlightSegment struct {
+	DepartureDateTime   string           `json:"DepartureDateTime"`
+	ArrivalDateTime     string           `json:"ArrivalDateTime"`
+	FlightNumber        string           `json:"FlightNumber"`
+	NumberInParty       string           `json:"NumberInParty"`
+	ResBookDesigCode    string           `json:"ResBookDesigCode"`
+	Status              string           `json:"Status"`
+	OriginLocation      LocationCode     `json:"OriginLocation"`
+	DestinationLocation LocationCode     `json:"DestinationLocation"`
+	MarketingAirline    MarketingAirline `json:"MarketingAirline"`
+	OperatingAirline    *AirlineCode     `json:"OperatingAirline,omitempty"`
+}
+
+// LocationCode is an airport code
+type LocationCode struct {
+	LocationCode string `json:"LocationCode"`
+}
+
+// MarketingAirline is the selling carrier and its flight number
+type MarketingAirline struct {
+	Code         string `json:"Code"`
+	FlightNumber string `json:"FlightNumber"`
+}
+
+// AirlineCode is a carrier code
+type AirlineCode struct {
+	Code string `json:"Code"`
+}
+
+// AirBookRedisplayReservation waits for the airline to confirm the segments
+type AirBookRedisplayReservation struct {
+	NumAttempts  int `json:"NumAttempts"`
+	WaitInterval int `json:"WaitInterval"`
+}
+
+// AirPriceRQ prices the booked segments and stores the price quote
+type AirPriceRQ struct {
+	PriceRequestInformation PriceRequestInformation `json:"PriceRequestInformation"`
+}
+
+// PriceRequestInformation holds pricing qualifiers
+type PriceRequestInformation struct {
+	Retain             bool               `json:"Retain"`
+	OptionalQualifiers OptionalQualifiers `json:"OptionalQualifiers"`
+}
+
+// OptionalQualifiers holds pricing qualifiers
+type OptionalQualifiers struct {
+	PricingQualifiers PricingQualifiers `json:"PricingQualifiers"`
+}
+
+// PricingQualifiers lists the passenger types to price
+type PricingQualifiers struct {
+	PassengerType []PricingPassengerType `json:"PassengerType"`
+}
+
+// PricingPassengerType is a passenger type and quantity to price
+type PricingPassengerType struct {
+	Code     string `json:"Code"`
+	Quantity string `json:"Quantity"`
+}
+
+// SpecialReqDetails carries special service requests
+type SpecialReqDetails struct {
+	SpecialService SpecialService `json:"SpecialService"`
+}
+
+// SpecialService carries special service requests
+type SpecialService struct {
+	SpecialServiceInfo SpecialServiceInfo `json:"SpecialServiceInfo"`
+}
+
+// SpecialServiceInfo holds APIS and secure flight data
+type SpecialServiceInfo struct {
+	AdvancePassenger []AdvancePassenger `json:"AdvancePassenger,omitempty"`
+	SecureFlight     []SecureFlight     `json:"SecureFlight,omitempty"`
+}
+
+// AdvancePassenger is the DOCS data of a passenger
+type AdvancePassenger struct {
+	Document      Document      `json:"Document"`
+	PersonName    SSRPersonName `json:"PersonName"`
+	SegmentNumber string        `json:"SegmentNumber"`
+}
+
+// Document is a travel document
+type Document struct {
+	Number             string `json:"Number"`
+	IssueCountry       string `json:"IssueCountry"`
+	NationalityCountry string `json:"NationalityCountry"`
+	ExpirationDate     string `json:"ExpirationDate"`
+	Type               string `json:"Type"`
+}
+
+// SSRPersonName identifies a passenger in special service requests
+type SSRPersonName struct {
+	NameNumber  string `json:"NameNumber"`
+	GivenName   string `json:"GivenName"`
+	Surname     string `json:"Surname"`
+	DateOfBirth string `json:"DateOfBirth"`
+	Gender      string `json:"Gender"`
+}
+
+// SecureFlight is the secure flight passenger data
+type SecureFlight struct {
+	PersonName    SSRPersonName `json:"PersonName"`
+	SegmentNumber string        `json:"SegmentNumber"`
+}
+
+// PostProcessing ends the transaction so the PNR is stored
+type PostProcessing struct {
+	EndTransaction EndTransaction `json:"EndTransaction"`
+}
+
+// EndTransaction commits the PNR
+type EndTransaction struct {
+	Source EndTransactionSource `json:"Source"`
+}
+
+// EndTransactionSource is the received-from field of the PNR
+type EndTransactionSource struct {
+	ReceivedFrom string `json:"ReceivedFrom"`
+}
+
+// CreatePNRResponseFormat wraps the Create Passenger Name Record response
+type CreatePNRResponseFormat struct {
+	CreatePassengerNameRecordRS CreatePassengerNameRecordRS `json:"CreatePassengerNameRecordRS"`
+}
+
+// CreatePassengerNameRecordRS is the outcome of creating a reservation
+type CreatePassengerNameRecordRS struct {
+	ApplicationResults ApplicationResults `json:"ApplicationResults"`
+	ItineraryRef       ItineraryRef       `json:"ItineraryRef"`
+	AirPrice           []AirPriceRS       `json:"AirPrice"`
+}
+
+// ApplicationResults reports the status of a Sabre transaction
+type ApplicationResults struct {
+	Status  string              `json:"status"`
+	Error   []ApplicationResult `json:"Error,omitempty"`
+	Warning []ApplicationResult `json:"Warning,omitempty"`
+}
+
+// ApplicationResult is an error or warning of a Sabre transaction
+type ApplicationResult struct {
+	Type           string          `json:"type"`
+	SystemSpecific []SystemResults `json:"SystemSpecificResults"`
+}
+
+// SystemResults holds the messages of an application result
+type SystemResults struct {
+	Message []ResultMessage `json:"Message"`
+}
+
+// ResultMessage is a single message line
+type ResultMessage struct {
+	Code    string `json:"code"`
+	Content string `json:"content"`
+}
+
+// ItineraryRef identifies the stored PNR
+type ItineraryRef struct {
+	ID string `json:"ID"`
+}
+
+// AirPriceRS is the stored price quote
+type AirPriceRS struct {
+	PriceQuote PriceQuote `json:"PriceQuote"`
+}
+
+// PriceQuote holds the price of the booked itinerary
+type PriceQuote struct {
+	MiscInformation MiscInformation `json:"MiscInformation"`
+	PricedItinerary PricedItinerary `json:"PricedItinerary"`
+}
+
+// MiscInformation holds the price quote header
+type MiscInformation struct {
+	HeaderInformation []HeaderInformation `json:"HeaderInformation"`
+}
+
+// HeaderInformation holds the last ticketing date and validating carrier of the price quote
+type HeaderInformation struct {
+	LastTicketingDate string        `json:"LastTicketingDate"`
+	ValidatingCarrier []AirlineCode `json:"ValidatingCarrier"`
+}
+
+// PricedItinerary holds the total price of the price quote
+type PricedItinerary struct {
+	CurrencyCode string `json:"CurrencyCode"`
+	TotalAmount  string `json:"TotalAmount"`
+}
@@ -0,0 +1,164 @@
+package sabre
+
+import (
+	"strings"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// BuildSabreRequest constructs the request payload for Sabre API
+// Args:
+//
+//	req - The provider-neutral search criteria
+//	PCC - Pseudo City Code for authentication
+//
+// Returns:
+//
+//	Formatted Sabre request structure
+func BuildSabreRequest(req *domain.SearchQuery, PCC string) SabreRequestFormat {
+	// Convert passenger info to Sabre format
+	passengers := []PassengerTypeQuantity{}
+	for _, p := range req.Passengers {
+		passengers = append(passengers, PassengerTypeQuantity{
+			Code:     p.Type,
+			Quantity: p.Count,
+		})
+	}
+
+	// Build origin-destination information for one-way trip
+	originDest := []OriginDest{
+		searchOriginDest(req.Origin, req.OriginType, req.OriginAirports,
+			req.Destination, req.DestinationType, req.DestinationAirports, req.DepartureDate),
+	}
+
+	// Add return leg if round trip
+	if req.TripType == domain.TripRoundTrip {
+		originDest = append(originDest, searchOriginDest(req.Destination, req.DestinationType, req.DestinationAirports,
+			req.Origin, req.OriginType, req.OriginAirports, req.ReturnDate))
+	}
+
+	// Ask Sabre for itineraries matching the preferences instead of filtering its 50 results.
+	// Sabre has no red-eye or overnight switch; those are filtered from the offers.
+	for i := range originDest {
+		applyPreferences(&originDest[i], req.Preferences, i)
+	}
+
+	// Construct and return the complete Sabre request
+	return SabreRequestFormat{
+		OTA_AirLowFareSearchRQ: OTA_AirLowFareSearchRQ{
+			Version: "5",
+			POS: POS{
+				Source: []Source{
+					{
+						PseudoCityCode: PCC,
+						RequestorID: RequestorID{
+							CompanyName: CompanyName{
+								Code: "TN",
+							},
+							ID:   "1",
+							Type: "1",
+						},
+					},
+				},
+			},
+			OriginDestinationInformation: originDest,
+			TravelerInfoSummary: TravelerInfoSummary{
+				AirTravelerAvail: []AirTravelerAvail{
+					{
+						PassengerTypeQuantity: passengers,
+					},
+				},
+				// Price every fare family so the offers can show a brand comparison
+				PriceRequestInformation: &SearchPriceRequestInformation{
+					TPA_Extensions: PriceRequestExtensions{
+						BrandedFareIndicators: BrandedFareIndicators{
+							MultipleBrandedFares:   true,
+							ReturnBrandAncillaries: true,
+						},
+					},
+				},
+			},
+			TravelPreferences: TravelPreferences{
+				Baggage: Baggage{
+					CarryOnInfo: true,
+					Description: true,
+					RequestType: "C",
+				},
+			},
+			TPA_Extensions: TPAExtensions{
+				IntelliSellTransaction: IntelliSellTransaction{
+					RequestType: RequestType{
+						Name: "50ITINS", // Request up to 50 itineraries
+					},
+				},
+			},
+		},
+	}
+}
+
+// searchOriginDest builds one direction of a search
+// City codes use Sabre's city location type so every airport of the city is
+// searched; for a list of airports the first one is the location and the others
+// are sent as sister locations.
+func searchOriginDest(origin, originType string, originAirports []string, destination, destinationType string, destinationAirports []string, date string) OriginDest {
+	od := OriginDest{
+		OriginLocation:      requestLocation(origin, originType),
+		DestinationLocation: requestLocation(destination, destinationType),
+		DepartureDateTime:   date,
+	}
+
+	var ext OriginDestExtensions
+	if originType != domain.LocationCity && len(originAirports) > 1 {
+		for _, airport := range originAirports[1:] {
+			ext.SisterOriginLocation = append(ext.SisterOriginLocation, SisterLocation{LocationCode: airport})
+		}
+	}
+	if destinationType != domain.LocationCity && len(destinationAirports) > 1 {
+		for _, airport := range destinationAirports[1:] {
+			ext.SisterDestinationLocation = append(ext.SisterDestinationLocation, SisterLocation{LocationCode: airport})
+		}
+	}
+	if len(ext.SisterOriginLocation) > 0 || len(ext.SisterDestinationLocation) > 0 {
+		od.TPA_Extensions = &ext
+	}
+	return od
+}
+
+// requestLocation maps a search location onto Sabre's location types
+func requestLocation(code, locationType string) RequestLocation {
+	if locationType == domain.LocationCity {
+		return RequestLocation{LocationCode: code, LocationType: "C"} // Multi-airport city
+	}
+	return RequestLocation{LocationCode: code, LocationType: "A"} // Airport
+}
+
+// applyPreferences adds the time windows and connection limits of a leg
+func applyPreferences(od *OriginDest, prefs *domain.SearchPreferences, leg int) {
+	if prefs == nil {
+		return
+	}
+	if w := prefs.Window(leg); w != nil {
+		od.DepartureWindow = timeWindow(w.DepartureAfter, w.DepartureBefore)
+		od.ArrivalWindow = timeWindow(w.ArrivalAfter, w.ArrivalBefore)
+	}
+	if prefs.MinConnection > 0 || prefs.MaxConnection > 0 {
+		if od.TPA_Extensions == nil {
+			od.TPA_Extensions = &OriginDestExtensions{}
+		}
+		od.TPA_Extensions.ConnectionTime = &ConnectionTime{Min: prefs.MinConnection, Max: prefs.MaxConnection}
+	}
+}
+
+// timeWindow formats HH:MM bounds as a Sabre HHMMHHMM window; open bounds span the whole day
+func timeWindow(after, before string) string {
+	if after == "" && before == "" {
+		return ""
+	}
+	if after == "" {
+		after = "00:00"
+	}
+	if before == "" {
+		before = "23:59"
+	}
+	return strings.ReplaceAll(after, ":", "") + strings.ReplaceAll(before, ":", "")
+}
@@ -0,0 +1,149 @@
+package sabre
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// revalidatePath is the Revalidate Itinerary endpoint, relative to the base URL
+const revalidatePath = "/v5/shop/flights/revalidate"
+
+// BuildRevalidateRequest constructs the Revalidate Itinerary payload for a selection
+// Args:
+//
+//	sel - The flights, booking classes and passengers to price
+//	PCC - Pseudo City Code for authentication
+//
+// Returns:
+//
+//	Formatted Sabre request structure
+func BuildRevalidateRequest(sel *domain.ItinerarySelection, PCC string) SabreRequestFormat {
+	passengers := []PassengerTypeQuantity{}
+	for _, p := range sel.Passengers {
+		passengers = append(passengers, PassengerTypeQuantity{
+			Code:     p.Type,
+			Quantity: p.Count,
+		})
+	}
+
+	// Every leg is pinned to the selected flights and classes
+	originDest := make([]OriginDest, 0, len(sel.Legs))
+	for i, leg := range sel.Legs {
+		if len(leg.Segments) == 0 {
+			continue
+		}
+		flights := make([]RequestFlight, 0, len(leg.Segments))
+		for _, seg := range leg.Segments {
+			flights = append(flights, RequestFlight{
+				Number:              seg.FlightNumber,
+				DepartureDateTime:   seg.DepartureDateTime,
+				ArrivalDateTime:     seg.ArrivalDateTime,
+				Type:                "A",
+				ClassOfService:      seg.BookingClass,
+				OriginLocation:      RequestLocation{LocationCode: seg.Origin, LocationType: "A"},
+				DestinationLocation: RequestLocation{LocationCode: seg.Destination, LocationType: "A"},
+				Airline: RequestAirline{
+					Marketing: seg.MarketingCarrier,
+					Operating: seg.OperatingCarrier,
+				},
+			})
+		}
+		first, last := leg.Segments[0], leg.Segments[len(leg.Segments)-1]
+		originDest = append(originDest, OriginDest{
+			RPH:                 strconv.Itoa(i + 1),
+			OriginLocation:      RequestLocation{LocationCode: first.Origin, LocationType: "A"},
+			DestinationLocation: RequestLocation{LocationCode: last.Destination, LocationType: "A"},
+			DepartureDateTime:   first.DepartureDateTime,
+			TPA_Extensions:      &OriginDestExtensions{Flight: flights},
+		})
+	}
+
+	return SabreRequestFormat{
+		OTA_AirLowFareSearchRQ: OTA_AirLowFareSearchRQ{
+			Version: "5",
+			POS: POS{
+				Source: []Source{
+					{
+						PseudoCityCode: PCC,
+						RequestorID: RequestorID{
+							CompanyName: CompanyName{
+								Code: "TN",
+							},
+							ID:   "1",
+							Type: "1",
+						},
+					},
+				},
+			},
+			OriginDestinationInformation: originDest,
+			TravelerInfoSummary: TravelerInfoSummary{
+				AirTravelerAvail: []AirTravelerAvail{
+					{
+						PassengerTypeQuantity: passengers,
+					},
+				},
+			},
+			TravelPreferences: TravelPreferences{
+				Baggage: Baggage{
+					CarryOnInfo: true,
+					Description: true,
+					RequestType: "C",
+				},
+				TPA_Extensions: &TravelPreferencesExtensions{
+					// Price the booking classes as given rather than rebooking
+					VerificationItinCallLogic: &VerificationItinCallLogic{Value: "B"},
+				},
+			},
+			TPA_Extensions: TPAExtensions{
+				IntelliSellTransaction: IntelliSellTransaction{
+					RequestType: RequestType{
+						Name: "50ITINS",
+					},
+				},
+			},
+		},
+	}
+}
+
+// Revalidate prices a selected itinerary against live availability
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	sel - The flights, booking classes and passengers to price
+//
+// Returns:
+//
+//	Pointer to SearchResult with the current offers, or an error wrapping
+//	domain.ErrNoAvailability when the flights can no longer be sold
+func (c *SabreClient) Revalidate(ctx context.Context, sel *domain.ItinerarySelection) (*domain.SearchResult, error) {
+	body, err := c.doRequest(ctx, "POST", c.BaseURL+revalidatePath, BuildRevalidateRequest(sel, c.PCC))
+	if err != nil {
+		return nil, err
+	}
+
+	var sabreResp SabreResponse
+	if err := json.Unmarshal(body, &sabreResp); err != nil {
+		return nil, fmt.Errorf("invalid response format from Sabre API: %v", err)
+	}
+
+	// Any processing error means the itinerary cannot be priced as selected
+	for _, msg := range sabreResp.GroupedItineraryResponse.Messages {
+		if msg.Severity == SeverityError {
+			return nil, fmt.Errorf("%w: %s", domain.ErrNoAvailability, msg.Text)
+		}
+	}
+	if sabreResp.GroupedItineraryResponse.Statistics.ItineraryCount == 0 {
+		return nil, domain.ErrNoAvailability
+	}
+
+	result, err := ParseSabreResponse(sabreResp, c.AirportTimes)
+	if err != nil {
+		return nil, err
+	}
+	c.tagSource(result)
+	return result, nil
+}
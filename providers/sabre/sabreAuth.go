@@ -0,0 +1,105 @@
+package sabre
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// tokenTimeout bounds a token request, which every other request of the client waits for
+const tokenTimeout = 30 * time.Second
+
+// GetToken retrieves an authentication token from Sabre's API
+// It updates the SabreClient's Token field with the new access token
+// Returns:
+//
+//	error - Any error encountered during the token retrieval process
+func (c *SabreClient) GetToken() error {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	token, err := c.fetchToken()
+	if err != nil {
+		return err
+	}
+	c.Token = token
+	return nil
+}
+
+// accessToken returns the current token, fetching one first when there is none
+// Concurrent requests share the client, so they wait for a single fetch.
+func (c *SabreClient) accessToken() (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.Token == "" {
+		token, err := c.fetchToken()
+		if err != nil {
+			return "", err
+		}
+		c.Token = token
+	}
+	return c.Token, nil
+}
+
+// expireToken drops a token Sabre rejected, so the next request fetches a new one
+// A token already replaced by another request is kept.
+func (c *SabreClient) expireToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.Token == token {
+		c.Token = ""
+	}
+}
+
+// fetchToken requests a new access token with the client credentials grant
+func (c *SabreClient) fetchToken() (string, error) {
+	url := c.SABREAUTHURL // Sabre's certification token endpoint
+
+	// Encode client ID and secret separately using base64
+	encodedID := base64.StdEncoding.EncodeToString([]byte(c.ClientID))
+	encodedSecret := base64.StdEncoding.EncodeToString([]byte(c.ClientSecret))
+
+	// Combine encoded credentials with a colon separator, then encode them again for the Basic Auth header
+	creds := base64.StdEncoding.EncodeToString([]byte(encodedID + ":" + encodedSecret))
+
+	// Prepare the payload for client credentials grant type
+	payload := strings.NewReader("grant_type=client_credentials")
+
+	// Create the HTTP request
+	req, err := http.NewRequest("POST", url, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %v", err)
+	}
+
+	// Set necessary headers for authentication
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded") // Required for form data
+	req.Header.Set("Authorization", "Basic "+creds)                     // Basic Auth with encoded credentials
+
+	// Execute the request; requests waiting for the token are held up while it runs
+	client := &http.Client{Timeout: tokenTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %v", err)
+	}
+	defer resp.Body.Close() // Ensure the response body is closed after use
+
+	// Check if the response status is successful
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("token request returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Define a struct to parse the token response
+	var tokenResp struct {
+		AccessToken string `json:"access_token"` // Field to capture the access token
+	}
+
+	// Decode the JSON response into the struct
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %v", err)
+	}
+	return tokenResp.AccessToken, nil
+}
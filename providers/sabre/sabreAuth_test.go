@@ -0,0 +1,58 @@
+package sabre
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDoRequestSharesToken(t *testing.T) {
+	var fetches atomic.Int32
+	var expired atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			n := fetches.Add(1)
+			fmt.Fprintf(w, `{"access_token": "token-%d"}`, n)
+			return
+		}
+		if expired.Load() && r.Header.Get("Authorization") == "Bearer token-1" {
+			http.Error(w, "expired", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	t.Cleanup(server.Close)
+	client := &SabreClient{SABREAUTHURL: server.URL + "/token", BaseURL: server.URL}
+
+	// Searches, bookings and ticketing share the client
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.doRequest(context.Background(), "GET", server.URL+"/search", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("concurrent requests fetched %d tokens, want 1", got)
+	}
+
+	// A rejected token is fetched again on the next request
+	expired.Store(true)
+	if _, err := client.doRequest(context.Background(), "GET", server.URL+"/search", nil); err == nil {
+		t.Fatal("expired token was accepted")
+	}
+	if _, err := client.doRequest(context.Background(), "GET", server.URL+"/search", nil); err != nil {
+		t.Fatalf("request after the token expired: %v", err)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("fetched %d tokens, want 2", got)
+	}
+}
@@ -0,0 +1,179 @@
+package sabre
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/config"
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// ProviderName identifies Sabre as the source of offers and warnings
+const ProviderName = "sabre"
+
+// SabreClient represents a client for interacting with the Sabre API
+type SabreClient struct {
+	ClientID     string         // API client ID for authentication
+	ClientSecret string         // API client secret for authentication
+	Token        string         // Current access token (refreshed as needed); guarded by tokenMu once requests run
+	URL          string         // Sabre API endpoint URL
+	PCC          string         // Pseudo City Code for agency identification
+	SABREAUTHURL string         // Sabre authentication endpoint URL
+	BaseURL      string         // Root URL of Sabre's REST APIs
+	TimeZone     *time.Location // Time zone of the PCC's agency, where its tickets are issued
+
+	AirportTimes interfaces.AirportTimes // Places flight times on the calendar; nil leaves only the local clock times
+
+	tokenMu sync.Mutex // Serializes token fetches; searches, bookings and ticketing share the client
+}
+
+// NewSabreClient creates and initializes a new SabreClient instance
+// Args:
+//
+//	Config - Application configuration holding the Sabre credentials and URLs
+//	PCC - Pseudo City Code the client searches under
+//	airportTimes - Airport time zones used to date departures and arrivals
+//
+// Returns:
+//
+//	Pointer to a new SabreClient instance
+func NewSabreClient(Config *config.Config, PCC string, airportTimes interfaces.AirportTimes) *SabreClient {
+	return &SabreClient{
+		ClientID:     Config.ClientID,
+		ClientSecret: Config.ClientSecret,
+		PCC:          PCC,
+		URL:          Config.URL,
+		SABREAUTHURL: Config.SABREAUTHURL,
+		BaseURL:      Config.SabreBaseURL,
+		TimeZone:     Config.PCCTimeZone,
+		AirportTimes: airportTimes,
+	}
+}
+
+// Name returns the provider name reported on every offer
+// The PCC is included so results from several PCCs can be told apart.
+func (c *SabreClient) Name() string {
+	return ProviderName + ":" + c.PCC
+}
+
+// SearchFlights executes a flight search using Sabre's Bargain Finder Max API
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The provider-neutral search criteria
+//
+// Returns:
+//
+//	Pointer to SearchResult with search results or an error if the request fails
+func (c *SabreClient) SearchFlights(ctx context.Context, req *domain.SearchQuery) (*domain.SearchResult, error) {
+	// Build the Sabre-specific request format from our internal request
+	sabreReq := BuildSabreRequest(req, c.PCC)
+
+	// Execute the request
+	body, err := c.doRequest(ctx, "POST", c.URL, sabreReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the Sabre response into our structure
+	var sabreResp SabreResponse
+	if err := json.Unmarshal(body, &sabreResp); err != nil {
+		return nil, fmt.Errorf("invalid response format from Sabre API: %v (raw response: %s)", err, string(body))
+	}
+
+	// Any error fails the search; other messages explain a search without
+	// itineraries, or are reported with the results
+	if err := messagesError(sabreResp.GroupedItineraryResponse.Messages); err != nil {
+		return nil, err
+	}
+	if sabreResp.GroupedItineraryResponse.Statistics.ItineraryCount == 0 {
+		return nil, noResultsError(sabreResp.GroupedItineraryResponse.Messages)
+	}
+
+	// Parse the response into our flight model, tagging everything with its source
+	result, err := ParseSabreResponse(sabreResp, c.AirportTimes)
+	if err != nil {
+		return nil, err
+	}
+	c.tagSource(result)
+	return result, nil
+}
+
+// tagSource marks every offer and warning of a result as coming from this client
+func (c *SabreClient) tagSource(result *domain.SearchResult) {
+	for i := range result.Offers {
+		result.Offers[i].Source = c.Name()
+	}
+	for i := range result.Warnings {
+		result.Warnings[i].Source = c.Name()
+	}
+}
+
+// doRequest sends an authenticated JSON request to a Sabre REST endpoint
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	method - HTTP method to use
+//	url - Full endpoint URL
+//	payload - Request body marshalled as JSON; nil sends no body
+//
+// Returns:
+//
+//	Raw body of a successful response or an error if the request fails
+func (c *SabreClient) doRequest(ctx context.Context, method, url string, payload interface{}) ([]byte, error) {
+	// Ensure we have a valid token; fetch one if not present
+	token, err := c.accessToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain authentication token: %v", err)
+	}
+
+	// Marshal the request into JSON
+	var reqBody io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %v", err)
+		}
+		reqBody = bytes.NewBuffer(raw)
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sabre request: %v", err)
+	}
+
+	// Set required headers
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	// Execute the request
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("sabre request failed: %v", err)
+	}
+	defer resp.Body.Close() // Ensure body is closed after we're done
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	// Check if the request was successful; an expired token is fetched again on the next request
+	if resp.StatusCode == http.StatusUnauthorized {
+		c.expireToken(token)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("sabre request returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
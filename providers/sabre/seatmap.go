@@ -0,0 +1,166 @@
+package sabre
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// getSeatsPath is the Get Seats endpoint, relative to the base URL
+const getSeatsPath = "/v1/offers/getseats"
+
+// IATA seat characteristic codes mapped to our normalized names
+var seatCharacteristics = map[string]string{
+	"W":  domain.SeatWindow,
+	"A":  domain.SeatAisle,
+	"9":  domain.SeatMiddle,
+	"E":  domain.SeatExitRow,
+	"L":  domain.SeatExtraLegroom,
+	"K":  domain.SeatBulkhead,
+	"B":  domain.SeatBassinet,
+	"H":  domain.SeatAccessible,
+	"1A": domain.SeatNoInfant,
+	"1B": domain.SeatNoChild,
+	"CH": domain.SeatChargeable,
+	"O":  domain.SeatPreferred,
+	"1D": domain.SeatRestricted,
+	"OW": domain.SeatOverWing,
+	"LA": domain.SeatNearLavatory,
+	"GN": domain.SeatNearGalley,
+}
+
+// BuildGetSeatsRequest constructs the Get Seats payload for a single flight
+// Args:
+//
+//	query - The flight the seat map is requested for
+//	PCC - Pseudo City Code the request is made from
+//
+// Returns:
+//
+//	Formatted Sabre request structure
+func BuildGetSeatsRequest(query *domain.SeatMapQuery, PCC string) GetSeatsRequest {
+	segment := PaxSegment{
+		ID:        "1",
+		Departure: SeatsStation{LocationCode: query.Origin, Date: query.DepartureDate},
+		Arrival:   SeatsStation{LocationCode: query.Destination},
+		MarketingCarrier: SeatsCarrier{
+			CarrierCode:         query.MarketingCarrier,
+			CarrierFlightNumber: strconv.Itoa(query.FlightNumber),
+		},
+		BookingClassCode: query.BookingClass,
+	}
+	if query.OperatingCarrier != "" && query.OperatingCarrier != query.MarketingCarrier {
+		segment.OperatingCarrier = &SeatsCarrier{CarrierCode: query.OperatingCarrier}
+	}
+
+	return GetSeatsRequest{
+		RequestType: "payload",
+		Request: GetSeatsPayload{
+			PaxSegments: []PaxSegment{segment},
+			Passengers:  []SeatsPassenger{{ID: "1", PassengerType: domain.PassengerAdult}},
+		},
+		PointOfSale: PointOfSale{
+			Location:      PointOfSaleLocation{CityCode: PCC},
+			AgentDutyCode: "*",
+		},
+	}
+}
+
+// SeatMap retrieves the seat layout and availability of a flight
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	query - The flight the seat map is requested for
+//
+// Returns:
+//
+//	Pointer to the normalized SeatMap or an error if Sabre has no seat map for the flight
+func (c *SabreClient) SeatMap(ctx context.Context, query *domain.SeatMapQuery) (*domain.SeatMap, error) {
+	body, err := c.doRequest(ctx, "POST", c.BaseURL+getSeatsPath, BuildGetSeatsRequest(query, c.PCC))
+	if err != nil {
+		return nil, err
+	}
+
+	var seatsResp GetSeatsResponse
+	if err := json.Unmarshal(body, &seatsResp); err != nil {
+		return nil, fmt.Errorf("invalid response format from Sabre API: %v", err)
+	}
+	if len(seatsResp.Errors) > 0 {
+		return nil, tripError("seat map", query.MarketingCarrier+strconv.Itoa(query.FlightNumber), seatsResp.Errors)
+	}
+	if len(seatsResp.SeatMaps) == 0 {
+		return nil, fmt.Errorf("%w: no seat map for %s%d", domain.ErrSeatMapUnavailable, query.MarketingCarrier, query.FlightNumber)
+	}
+	return c.ParseSeatMap(&seatsResp, query), nil
+}
+
+// ParseSeatMap converts a Get Seats response into the normalized seat map
+// Args:
+//
+//	resp - The Get Seats response
+//	query - The flight the seat map was requested for
+//
+// Returns:
+//
+//	Pointer to the normalized SeatMap
+func (c *SabreClient) ParseSeatMap(resp *GetSeatsResponse, query *domain.SeatMapQuery) *domain.SeatMap {
+	// Chargeable seats reference their price through offer items
+	prices := make(map[string]*domain.Price)
+	if resp.ALaCarteOffer != nil {
+		for _, item := range resp.ALaCarteOffer.OfferItems {
+			price := item.UnitPrice
+			prices[item.OfferItemID] = &domain.Price{
+				Total:    price.TotalAmount.Amount,
+				Base:     price.BaseAmount.Amount,
+				Taxes:    price.TaxAmount.Amount,
+				Currency: price.TotalAmount.CurrencyCode,
+			}
+		}
+	}
+
+	seatMap := &domain.SeatMap{
+		Source: c.Name(),
+		Flight: query.MarketingCarrier + strconv.Itoa(query.FlightNumber),
+	}
+	for _, compartment := range resp.SeatMaps[0].CabinCompartments {
+		cabin := domain.Cabin{
+			Code:     compartment.CabinType.CabinTypeCode,
+			FirstRow: compartment.FirstRow,
+			LastRow:  compartment.LastRow,
+		}
+		for _, col := range compartment.ColumnIDs {
+			cabin.Columns = append(cabin.Columns, col.Value)
+		}
+
+		for _, row := range compartment.SeatRows {
+			seatRow := domain.SeatRow{Number: row.Row}
+			for _, s := range row.Seats {
+				seat := domain.Seat{
+					Number:    strconv.Itoa(row.Row) + s.Column,
+					Column:    s.Column,
+					Available: s.OccupationStatusCode == "F",
+				}
+				seen := make(map[string]bool)
+				for _, code := range s.Characteristics {
+					if name, ok := seatCharacteristics[code]; ok && !seen[name] {
+						seen[name] = true
+						seat.Characteristics = append(seat.Characteristics, name)
+					}
+				}
+				for _, ref := range s.OfferItemRefIDs {
+					if price, ok := prices[ref]; ok {
+						seat.Price = price
+						break
+					}
+				}
+				seatRow.Seats = append(seatRow.Seats, seat)
+			}
+			cabin.Rows = append(cabin.Rows, seatRow)
+		}
+		seatMap.Cabins = append(seatMap.Cabins, cabin)
+	}
+	return seatMap
+}
@@ -0,0 +1,126 @@
+package sabre
+
+// GetSeatsRequest asks for the seat map of one flight in payload mode
+type GetSeatsRequest struct {
+	RequestType string          `json:"requestType"`
+	Request     GetSeatsPayload `json:"request"`
+	PointOfSale PointOfSale     `json:"pointOfSale"`
+}
+
+// GetSeatsPayload describes the flight and passengers the seat map is for
+type GetSeatsPayload struct {
+	PaxSegments []PaxSegment     `json:"paxSegments"`
+	Passengers  []SeatsPassenger `json:"paxes"`
+}
+
+// PaxSegment is a flight segment of the seat map request
+type PaxSegment struct {
+	ID               string        `json:"id"`
+	Departure        SeatsStation  `json:"departure"`
+	Arrival          SeatsStation  `json:"arrival"`
+	MarketingCarrier SeatsCarrier  `json:"marketingCarrierInfo"`
+	OperatingCarrier *SeatsCarrier `json:"operatingCarrierInfo,omitempty"`
+	CabinType        *SeatsCabin   `json:"cabinType,omitempty"`
+	BookingClassCode string        `json:"bookingClassCode,omitempty"`
+}
+
+// SeatsStation is the departure or arrival of a segment
+type SeatsStation struct {
+	LocationCode string `json:"locationCode"`
+	Date         string `json:"date,omitempty"` // Format: YYYY-MM-DD
+}
+
+// SeatsCarrier is a carrier and flight number
+type SeatsCarrier struct {
+	CarrierCode         string `json:"carrierCode"`
+	CarrierFlightNumber string `json:"carrierFlightNumber"`
+}
+
+// SeatsCabin is a cabin code
+type SeatsCabin struct {
+	CabinTypeCode string `json:"cabinTypeCode"`
+}
+
+// SeatsPassenger is a passenger the seat map is priced for
+type SeatsPassenger struct {
+	ID            string `json:"id"`
+	PassengerType string `json:"ptc"`
+}
+
+// PointOfSale identifies the agency requesting the seat map
+type PointOfSale struct {
+	Location      PointOfSaleLocation `json:"location"`
+	AgentDutyCode string              `json:"agentDutyCode"`
+}
+
+// PointOfSaleLocation is the PCC of the requesting agency
+type PointOfSaleLocation struct {
+	CountryCode string `json:"countryCode,omitempty"`
+	CityCode    string `json:"cityCode"`
+}
+
+// GetSeatsResponse is the seat availability of the requested flight
+type GetSeatsResponse struct {
+	SeatMaps      []SeatMapRS    `json:"seatMaps"`
+	ALaCarteOffer *ALaCarteOffer `json:"aLaCarteOffer"`
+	Errors        []TripError    `json:"errors"`
+}
+
+// SeatMapRS is the seat map of one segment
+type SeatMapRS struct {
+	PaxSegmentRefID   string             `json:"paxSegmentRefId"`
+	CabinCompartments []CabinCompartment `json:"cabinCompartments"`
+}
+
+// CabinCompartment is a cabin of the aircraft
+type CabinCompartment struct {
+	CabinType SeatsCabin  `json:"cabinType"`
+	ColumnIDs []ColumnID  `json:"columnIds"`
+	FirstRow  int         `json:"firstRow"`
+	LastRow   int         `json:"lastRow"`
+	SeatRows  []SeatRowRS `json:"seatRows"`
+}
+
+// ColumnID is a seat letter of a cabin and its position
+type ColumnID struct {
+	Value    string `json:"value"`
+	Position string `json:"position"` // W window, A aisle, C centre
+}
+
+// SeatRowRS is a row of seats
+type SeatRowRS struct {
+	Row   int      `json:"row"`
+	Seats []SeatRS `json:"seats"`
+}
+
+// SeatRS is a single seat
+type SeatRS struct {
+	Column               string   `json:"column"`
+	OccupationStatusCode string   `json:"occupationStatusCode"` // F free, O occupied, Z blocked
+	Characteristics      []string `json:"characteristicCodes"`
+	OfferItemRefIDs      []string `json:"offerItemRefIds"`
+}
+
+// ALaCarteOffer holds the prices of chargeable seats
+type ALaCarteOffer struct {
+	OfferItems []SeatOfferItem `json:"offerItems"`
+}
+
+// SeatOfferItem is the price of a group of seats
+type SeatOfferItem struct {
+	OfferItemID string        `json:"offerItemId"`
+	UnitPrice   SeatUnitPrice `json:"unitPrice"`
+}
+
+// SeatUnitPrice is the price of one seat
+type SeatUnitPrice struct {
+	TotalAmount SeatAmount `json:"totalAmount"`
+	BaseAmount  SeatAmount `json:"baseAmount"`
+	TaxAmount   SeatAmount `json:"taxAmount"`
+}
+
+// SeatAmount is an amount and currency
+type SeatAmount struct {
+	Amount       float64 `json:"amount"`
+	CurrencyCode string  `json:"curCode"`
+}
@@ -0,0 +1,150 @@
+package sabre
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// airTicketPath is the Enhanced Air Ticket endpoint, relative to the base URL
+const airTicketPath = "/v1.3.0/air/ticket"
+
+// BuildAirTicketRequest constructs the Enhanced Air Ticket payload
+// Args:
+//
+//	req - The reservation, validating carrier, form of payment and travelers to ticket
+//	PCC - Pseudo City Code the tickets are issued in
+//
+// Returns:
+//
+//	Formatted Sabre request structure
+func BuildAirTicketRequest(req *domain.TicketingRequest, PCC string) AirTicketRequestFormat {
+	fop := BasicFOP{Type: "CA"}
+	if req.Payment.Type == domain.PaymentTypeCard && req.Payment.Card != nil {
+		fop = BasicFOP{CCInfo: &CCInfo{PaymentCard: PaymentCardInfo{
+			Code:       req.Payment.Card.Code,
+			ExpireDate: req.Payment.Card.ExpiryDate,
+			Number:     req.Payment.Card.Number,
+		}}}
+	}
+
+	ticketing := TicketingInfo{
+		FOPQualifiers: FOPQualifiers{BasicFOP: fop},
+		// The price quote stored when the PNR was created is always record 1
+		PricingQualifiers: TicketPricingQualifiers{PriceQuote: []PriceQuoteRecord{{Record: []RecordNumber{{Number: 1}}}}},
+	}
+	// Travelers are named "n.1" in booking order, see BuildCreatePNRRequest
+	for _, position := range req.Travelers {
+		ticketing.PricingQualifiers.NameSelect = append(ticketing.PricingQualifiers.NameSelect, TicketNameSelect{NameNumber: strconv.Itoa(position) + ".1"})
+	}
+	if req.ValidatingCarrier != "" {
+		ticketing.MiscQualifiers = &TicketingMiscQualifiers{ValidatingCarrier: &AirlineCode{Code: req.ValidatingCarrier}}
+	}
+
+	return AirTicketRequestFormat{
+		AirTicketRQ: AirTicketRQ{
+			Version:          "1.3.0",
+			TargetCity:       PCC,
+			DesignatePrinter: DesignatePrinter{Printers: Printers{Ticket: TicketPrinter{CountryCode: "1"}}},
+			Itinerary:        ItineraryRef{ID: req.RecordLocator},
+			Ticketing:        []TicketingInfo{ticketing},
+			PostProcessing: PostProcessing{
+				EndTransaction: EndTransaction{Source: EndTransactionSource{ReceivedFrom: "FLIGHTSEARCH API"}},
+			},
+		},
+	}
+}
+
+// IssueTickets issues the tickets of a held reservation
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The reservation, validating carrier and form of payment
+//
+// Returns:
+//
+//	The issued tickets or an error if Sabre rejected the ticketing
+func (c *SabreClient) IssueTickets(ctx context.Context, req *domain.TicketingRequest) ([]domain.Ticket, error) {
+	body, err := c.doRequest(ctx, "POST", c.BaseURL+airTicketPath, BuildAirTicketRequest(req, c.PCC))
+	if err != nil {
+		return nil, err
+	}
+
+	var ticketResp AirTicketResponseFormat
+	if err := json.Unmarshal(body, &ticketResp); err != nil {
+		return nil, fmt.Errorf("invalid response format from Sabre API: %v", err)
+	}
+	rs := ticketResp.AirTicketRS
+	if rs.ApplicationResults.Status != "Complete" {
+		return nil, applicationError("ticketing", rs.ApplicationResults)
+	}
+
+	var tickets []domain.Ticket
+	for _, doc := range rs.Summary {
+		if doc.DocumentType != "" && doc.DocumentType != "TKT" {
+			continue // EMDs and other documents are not flight tickets
+		}
+		issueDate := doc.LocalIssueDate
+		if len(issueDate) > 10 {
+			issueDate = issueDate[:10]
+		}
+		tickets = append(tickets, domain.Ticket{
+			Number:    doc.DocumentNumber,
+			Traveler:  strings.TrimSpace(doc.FirstName + " " + doc.LastName),
+			IssueDate: issueDate,
+			Status:    domain.TicketIssued,
+		})
+	}
+	if len(tickets) == 0 {
+		return nil, fmt.Errorf("sabre ticketing returned no ticket numbers for %s", req.RecordLocator)
+	}
+	return tickets, nil
+}
+
+// VoidTickets voids issued tickets
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	locator - Record locator of the reservation
+//	numbers - Ticket numbers to void
+//
+// Returns:
+//
+//	An error if Sabre did not void every ticket
+func (c *SabreClient) VoidTickets(ctx context.Context, locator string, numbers []string) error {
+	body, err := c.doRequest(ctx, "POST", c.BaseURL+voidTicketsPath, VoidTicketsRequest{Tickets: numbers})
+	if err != nil {
+		return err
+	}
+
+	var voidResp VoidTicketsResponse
+	if err := json.Unmarshal(body, &voidResp); err != nil {
+		return fmt.Errorf("invalid response format from Sabre API: %v", err)
+	}
+	if len(voidResp.Errors) > 0 {
+		for _, e := range voidResp.Errors {
+			if strings.Contains(strings.ToUpper(e.Description), "VOID") && strings.Contains(strings.ToUpper(e.Description), "PERIOD") {
+				return fmt.Errorf("%w: %s", domain.ErrVoidWindowClosed, e.Description)
+			}
+		}
+		return tripError("void", locator, voidResp.Errors)
+	}
+	if len(voidResp.VoidedTickets) < len(numbers) {
+		return fmt.Errorf("sabre voided %d of %d tickets for %s", len(voidResp.VoidedTickets), len(numbers), locator)
+	}
+	return nil
+}
+
+// TicketingTimeZone returns the time zone the PCC issues tickets in
+// Sabre voids tickets until midnight in the issuing agency's time zone.
+func (c *SabreClient) TicketingTimeZone() *time.Location {
+	if c.TimeZone == nil {
+		return time.UTC
+	}
+	return c.TimeZone
+}
@@ -0,0 +1,108 @@
+package sabre
+
+// AirTicketRequestFormat wraps the Enhanced Air Ticket request
+type AirTicketRequestFormat struct {
+	AirTicketRQ AirTicketRQ `json:"AirTicketRQ"`
+}
+
+// AirTicketRQ issues the tickets of a stored reservation
+type AirTicketRQ struct {
+	Version          string           `json:"version"`
+	TargetCity       string           `json:"targetCity"`
+	DesignatePrinter DesignatePrinter `json:"DesignatePrinter"`
+	Itinerary        ItineraryRef     `json:"Itinerary"`
+	Ticketing        []TicketingInfo  `json:"Ticketing"`
+	PostProcessing   PostProcessing   `json:"PostProcessing"`
+}
+
+// DesignatePrinter selects the electronic ticket printer
+type DesignatePrinter struct {
+	Printers Printers `json:"Printers"`
+}
+
+// Printers lists the printers to designate
+type Printers struct {
+	Ticket TicketPrinter `json:"Ticket"`
+}
+
+// TicketPrinter is the ticket printer country
+type TicketPrinter struct {
+	CountryCode string `json:"CountryCode"`
+}
+
+// TicketingInfo issues tickets from a stored price quote
+type TicketingInfo struct {
+	FOPQualifiers     FOPQualifiers            `json:"FOP_Qualifiers"`
+	PricingQualifiers TicketPricingQualifiers  `json:"PricingQualifiers"`
+	MiscQualifiers    *TicketingMiscQualifiers `json:"MiscQualifiers,omitempty"`
+}
+
+// FOPQualifiers holds the form of payment
+type FOPQualifiers struct {
+	BasicFOP BasicFOP `json:"BasicFOP"`
+}
+
+// BasicFOP is a cash or credit card form of payment
+type BasicFOP struct {
+	Type   string  `json:"Type,omitempty"` // CA for cash
+	CCInfo *CCInfo `json:"CC_Info,omitempty"`
+}
+
+// CCInfo holds the payment card
+type CCInfo struct {
+	PaymentCard PaymentCardInfo `json:"PaymentCard"`
+}
+
+// PaymentCardInfo is a credit card
+type PaymentCardInfo struct {
+	Code       string `json:"Code"`
+	ExpireDate string `json:"ExpireDate"` // Format: YYYY-MM
+	Number     string `json:"Number"`
+}
+
+// TicketPricingQualifiers selects the price quote to ticket
+type TicketPricingQualifiers struct {
+	NameSelect []TicketNameSelect `json:"NameSelect,omitempty"` // Travelers to ticket; empty tickets them all
+	PriceQuote []PriceQuoteRecord `json:"PriceQuote"`
+}
+
+// TicketNameSelect selects a traveler by PNR name number
+type TicketNameSelect struct {
+	NameNumber string `json:"NameNumber"`
+}
+
+// PriceQuoteRecord selects stored price quotes by number
+type PriceQuoteRecord struct {
+	Record []RecordNumber `json:"Record"`
+}
+
+// RecordNumber is a stored price quote number
+type RecordNumber struct {
+	Number int `json:"Number"`
+}
+
+// TicketingMiscQualifiers holds the validating carrier override
+type TicketingMiscQualifiers struct {
+	AirExtras         *bool        `json:"AirExtras,omitempty"`
+	ValidatingCarrier *AirlineCode `json:"ValidatingCarrier,omitempty"`
+}
+
+// AirTicketResponseFormat wraps the Enhanced Air Ticket response
+type AirTicketResponseFormat struct {
+	AirTicketRS AirTicketRS `json:"AirTicketRS"`
+}
+
+// AirTicketRS is the outcome of ticketing
+type AirTicketRS struct {
+	ApplicationResults ApplicationResults `json:"ApplicationResults"`
+	Summary            []TicketSummary    `json:"Summary"`
+}
+
+// TicketSummary describes an issued document
+type TicketSummary struct {
+	DocumentNumber string `json:"DocumentNumber"`
+	DocumentType   string `json:"DocumentType"` // TKT for flight tickets
+	FirstName      string `json:"FirstName"`
+	LastName       string `json:"LastName"`
+	LocalIssueDate string `json:"LocalIssueDateTime"`
+}
@@ -0,0 +1,215 @@
+package sabre
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// Booking Management endpoints, relative to the base URL
+const (
+	getBookingPath    = "/v1/trip/orders/getBooking"
+	cancelBookingPath = "/v1/trip/orders/cancelBooking"
+	voidTicketsPath   = "/v1/trip/orders/voidFlightTickets"
+)
+
+// Segment status codes of flights that are no longer held
+var inactiveSegmentStatus = map[string]bool{
+	"HX": true, "NO": true, "UC": true, "UN": true, "XX": true,
+}
+
+// GetBooking retrieves a reservation by its record locator
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	locator - Record locator of the reservation
+//
+// Returns:
+//
+//	Pointer to the Booking or an error if Sabre could not retrieve it
+func (c *SabreClient) GetBooking(ctx context.Context, locator string) (*domain.Booking, error) {
+	body, err := c.doRequest(ctx, "POST", c.BaseURL+getBookingPath, GetBookingRequest{ConfirmationID: locator})
+	if err != nil {
+		return nil, err
+	}
+
+	var trip TripBooking
+	if err := json.Unmarshal(body, &trip); err != nil {
+		return nil, fmt.Errorf("invalid response format from Sabre API: %v", err)
+	}
+	if len(trip.Errors) > 0 {
+		return nil, tripError("booking retrieval", locator, trip.Errors)
+	}
+	return c.toBooking(locator, &trip), nil
+}
+
+// CancelBooking cancels some or all flights of a reservation
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	locator - Record locator of the reservation
+//	segmentIDs - IDs of the segments to cancel; empty cancels the whole reservation
+//
+// Returns:
+//
+//	Pointer to the updated Booking or an error if Sabre rejected the cancellation
+func (c *SabreClient) CancelBooking(ctx context.Context, locator string, segmentIDs []string) (*domain.Booking, error) {
+	cancelReq := CancelBookingRequest{
+		ConfirmationID:  locator,
+		RetrieveBooking: true,
+		CancelAll:       len(segmentIDs) == 0,
+	}
+	for _, id := range segmentIDs {
+		cancelReq.Flights = append(cancelReq.Flights, FlightItemRef{ItemID: id})
+	}
+
+	body, err := c.doRequest(ctx, "POST", c.BaseURL+cancelBookingPath, cancelReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var cancelResp CancelBookingResponse
+	if err := json.Unmarshal(body, &cancelResp); err != nil {
+		return nil, fmt.Errorf("invalid response format from Sabre API: %v", err)
+	}
+	if len(cancelResp.Errors) > 0 {
+		return nil, tripError("cancellation", locator, cancelResp.Errors)
+	}
+	if cancelResp.Booking == nil {
+		return &domain.Booking{RecordLocator: locator, Source: c.Name(), Status: domain.BookingCancelled}, nil
+	}
+	return c.toBooking(locator, cancelResp.Booking), nil
+}
+
+// toBooking converts a Booking Management reservation into the domain model
+func (c *SabreClient) toBooking(locator string, trip *TripBooking) *domain.Booking {
+	booking := &domain.Booking{
+		RecordLocator: locator,
+		Source:        c.Name(),
+	}
+
+	active := 0
+	for _, f := range trip.Flights {
+		booking.Segments = append(booking.Segments, domain.BookedSegment{
+			ID: f.ItemID,
+			SelectedSegment: domain.SelectedSegment{
+				MarketingCarrier:  f.AirlineCode,
+				FlightNumber:      f.FlightNumber,
+				OperatingCarrier:  f.OperatingAirlineCode,
+				Origin:            f.FromAirportCode,
+				Destination:       f.ToAirportCode,
+				DepartureDateTime: tripDateTime(f.DepartureDate, f.DepartureTime),
+				ArrivalDateTime:   tripDateTime(f.ArrivalDate, f.ArrivalTime),
+				BookingClass:      f.BookingClass,
+			},
+			Status: f.FlightStatusCode,
+		})
+		if !inactiveSegmentStatus[f.FlightStatusCode] {
+			active++
+		}
+	}
+
+	for _, t := range trip.Travelers {
+		traveler := domain.Traveler{
+			Type:        t.PassengerCode,
+			GivenName:   t.GivenName,
+			Surname:     t.Surname,
+			DateOfBirth: t.BirthDate,
+		}
+		if t.Gender != "" {
+			traveler.Gender = t.Gender[:1]
+		}
+		if len(t.IdentityDocuments) > 0 {
+			doc := t.IdentityDocuments[0]
+			docType := "P"
+			if doc.DocumentType != "PASSPORT" {
+				docType = "I"
+			}
+			traveler.Document = &domain.TravelDocument{
+				Type:           docType,
+				Number:         doc.DocumentNumber,
+				IssuingCountry: doc.IssuingCountryCode,
+				Nationality:    doc.CitizenshipCountryCode,
+				ExpiryDate:     doc.ExpiryDate,
+			}
+		}
+		booking.Travelers = append(booking.Travelers, traveler)
+	}
+
+	for _, fare := range trip.Fares {
+		if fare.LastTicketingDate != "" && (booking.TicketingTimeLimit == "" || fare.LastTicketingDate < booking.TicketingTimeLimit) {
+			booking.TicketingTimeLimit = fare.LastTicketingDate
+		}
+		if booking.ValidatingCarrier == "" {
+			booking.ValidatingCarrier = fare.AirlineCode
+		}
+	}
+	if len(trip.Payments.FlightTotals) > 0 {
+		totals := trip.Payments.FlightTotals[0]
+		total, err := strconv.ParseFloat(totals.Total, 64)
+		if err == nil {
+			base, _ := strconv.ParseFloat(totals.Subtotal, 64)
+			taxes, _ := strconv.ParseFloat(totals.Taxes, 64)
+			booking.Price = &domain.Price{Total: total, Base: base, Taxes: taxes, Currency: totals.CurrencyCode}
+		}
+	}
+
+	for _, t := range trip.FlightTickets {
+		ticket := domain.Ticket{
+			Number:    t.Number,
+			IssueDate: t.Date,
+			Status:    domain.TicketIssued,
+		}
+		if strings.EqualFold(t.TicketStatusName, "voided") {
+			ticket.Status = domain.TicketVoided
+		}
+		if t.TravelerIndex >= 1 && t.TravelerIndex <= len(trip.Travelers) {
+			traveler := trip.Travelers[t.TravelerIndex-1]
+			ticket.Traveler = traveler.GivenName + " " + traveler.Surname
+		}
+		booking.Tickets = append(booking.Tickets, ticket)
+	}
+
+	switch {
+	case active == 0:
+		booking.Status = domain.BookingCancelled
+	case trip.IsTicketed || len(booking.IssuedTickets()) > 0:
+		booking.Status = domain.BookingTicketed
+	default:
+		booking.Status = domain.BookingHeld
+	}
+	return booking
+}
+
+// tripDateTime joins a Booking Management date and time into our local date-time format
+func tripDateTime(date, clock string) string {
+	if clock == "" {
+		return date
+	}
+	if len(clock) == 5 {
+		clock += ":00"
+	}
+	return date + "T" + clock
+}
+
+// tripError turns Booking Management errors into an error
+// Unknown record locators are reported as domain.ErrBookingNotFound.
+func tripError(operation, locator string, errs []TripError) error {
+	var messages []string
+	notFound := false
+	for _, e := range errs {
+		messages = append(messages, e.Description)
+		if strings.Contains(e.Type, "NOT_FOUND") || strings.Contains(strings.ToUpper(e.Description), "NOT FOUND") {
+			notFound = true
+		}
+	}
+	text := strings.Join(messages, "; ")
+	if notFound {
+		return fmt.Errorf("%w: %s", domain.ErrBookingNotFound, locator)
+	}
+	return fmt.Errorf("sabre %s failed: %s", operation, text)
+}
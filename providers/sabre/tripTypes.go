@@ -0,0 +1,119 @@
+package sabre
+
+// GetBookingRequest retrieves a reservation through the Booking Management API
+type GetBookingRequest struct {
+	ConfirmationID string `json:"confirmationId"`
+}
+
+// CancelBookingRequest cancels flights of a reservation through the Booking Management API
+type CancelBookingRequest struct {
+	ConfirmationID  string          `json:"confirmationId"`
+	RetrieveBooking bool            `json:"retrieveBooking"`
+	CancelAll       bool            `json:"cancelAll,omitempty"`
+	Flights         []FlightItemRef `json:"flights,omitempty"`
+}
+
+// FlightItemRef identifies a flight of a reservation by its item ID
+type FlightItemRef struct {
+	ItemID string `json:"itemId"`
+}
+
+// CancelBookingResponse is the outcome of a cancellation
+type CancelBookingResponse struct {
+	Booking *TripBooking `json:"booking"`
+	Errors  []TripError  `json:"errors"`
+}
+
+// TripBooking is a reservation as returned by the Booking Management API
+type TripBooking struct {
+	ConfirmationID string         `json:"confirmationId"`
+	IsCancelable   bool           `json:"isCancelable"`
+	IsTicketed     bool           `json:"isTicketed"`
+	Flights        []TripFlight   `json:"flights"`
+	Travelers      []TripTraveler `json:"travelers"`
+	Fares          []TripFare     `json:"fares"`
+	Payments       TripPayments   `json:"payments"`
+	FlightTickets  []TripTicket   `json:"flightTickets"`
+	Errors         []TripError    `json:"errors"`
+}
+
+// TripTicket is a flight ticket of a reservation
+type TripTicket struct {
+	Number           string `json:"number"`
+	Date             string `json:"date"` // Issue date, format: YYYY-MM-DD
+	TravelerIndex    int    `json:"travelerIndex"`
+	TicketStatusName string `json:"ticketStatusName"` // e.g. Issued or Voided
+}
+
+// TripFlight is a flight segment of a reservation
+type TripFlight struct {
+	ItemID                string `json:"itemId"`
+	FlightNumber          int    `json:"flightNumber"`
+	AirlineCode           string `json:"airlineCode"`
+	OperatingFlightNumber int    `json:"operatingFlightNumber"`
+	OperatingAirlineCode  string `json:"operatingAirlineCode"`
+	FromAirportCode       string `json:"fromAirportCode"`
+	ToAirportCode         string `json:"toAirportCode"`
+	DepartureDate         string `json:"departureDate"` // Format: YYYY-MM-DD
+	DepartureTime         string `json:"departureTime"` // Format: HH:MM
+	ArrivalDate           string `json:"arrivalDate"`
+	ArrivalTime           string `json:"arrivalTime"`
+	BookingClass          string `json:"bookingClass"`
+	FlightStatusCode      string `json:"flightStatusCode"`
+}
+
+// TripTraveler is a passenger of a reservation
+type TripTraveler struct {
+	GivenName         string             `json:"givenName"`
+	Surname           string             `json:"surname"`
+	PassengerCode     string             `json:"passengerCode"`
+	BirthDate         string             `json:"birthDate"`
+	Gender            string             `json:"gender"` // MALE or FEMALE
+	IdentityDocuments []IdentityDocument `json:"identityDocuments"`
+}
+
+// IdentityDocument is a travel document stored in a reservation
+type IdentityDocument struct {
+	DocumentNumber         string `json:"documentNumber"`
+	DocumentType           string `json:"documentType"` // PASSPORT or NATIONAL_ID_CARD
+	IssuingCountryCode     string `json:"issuingCountryCode"`
+	CitizenshipCountryCode string `json:"citizenshipCountryCode"`
+	ExpiryDate             string `json:"expiryDate"`
+}
+
+// TripFare is a stored price quote of a reservation
+type TripFare struct {
+	AirlineCode       string `json:"airlineCode"` // Validating carrier
+	LastTicketingDate string `json:"lastTicketingDate"`
+}
+
+// TripPayments holds the price totals of a reservation
+type TripPayments struct {
+	FlightTotals []TripTotals `json:"flightTotals"`
+}
+
+// TripTotals is a price total of a reservation
+type TripTotals struct {
+	Subtotal     string `json:"subtotal"`
+	Taxes        string `json:"taxes"`
+	Total        string `json:"total"`
+	CurrencyCode string `json:"currencyCode"`
+}
+
+// TripError is an error reported by the Booking Management API
+type TripError struct {
+	Category    string `json:"category"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// VoidTicketsRequest voids flight tickets through the Booking Management API
+type VoidTicketsRequest struct {
+	Tickets []string `json:"tickets"`
+}
+
+// VoidTicketsResponse is the outcome of voiding tickets
+type VoidTicketsResponse struct {
+	VoidedTickets []string    `json:"voidedTickets"`
+	Errors        []TripError `json:"errors"`
+}
@@ -0,0 +1,75 @@
+package snapshots
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// MemoryStore keeps offer snapshots in process memory until they expire
+// Snapshots are lost on restart and are not shared between instances.
+type MemoryStore struct {
+	TTL time.Duration // How long a snapshot can be looked up after it was saved
+
+	mu        sync.Mutex
+	snapshots map[string]domain.OfferSnapshot
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates and initializes a new MemoryStore
+// Args:
+//
+//	ttl - How long a snapshot can be looked up after it was saved
+//
+// Returns:
+//
+//	Pointer to a new MemoryStore instance
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		TTL:       ttl,
+		snapshots: make(map[string]domain.OfferSnapshot),
+		now:       time.Now,
+	}
+}
+
+// Save stores a snapshot under its offer's itinerary ID, replacing any previous one
+// The snapshot's creation and expiry times are set.
+func (m *MemoryStore) Save(ctx context.Context, snapshot *domain.OfferSnapshot) error {
+	if snapshot.Offer.ItineraryID == "" {
+		return fmt.Errorf("offer %s has no itinerary ID", snapshot.Offer.ID)
+	}
+	now := m.now()
+	snapshot.CreatedAt = now
+	snapshot.ExpiresAt = now.Add(m.TTL)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshots[snapshot.Offer.ItineraryID] = *snapshot
+
+	// Expired snapshots are swept at most once per TTL
+	if now.After(m.nextSweep) {
+		for id, s := range m.snapshots {
+			if now.After(s.ExpiresAt) {
+				delete(m.snapshots, id)
+			}
+		}
+		m.nextSweep = now.Add(m.TTL)
+	}
+	return nil
+}
+
+// Get returns the snapshot saved under an itinerary ID
+// Returns domain.ErrOfferNotFound when there is none or it expired.
+func (m *MemoryStore) Get(ctx context.Context, itineraryID string) (*domain.OfferSnapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot, ok := m.snapshots[itineraryID]
+	if !ok || m.now().After(snapshot.ExpiresAt) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrOfferNotFound, itineraryID)
+	}
+	return &snapshot, nil
+}
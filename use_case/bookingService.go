@@ -0,0 +1,261 @@
+package use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// ticketingLockTTL bounds how long a ticketing operation holds its reservation's lock
+// It outlasts ticketingTimeout, so a lock is only left to expire when its holder died.
+const ticketingLockTTL = 2 * time.Minute
+
+// ticketingTimeout bounds the provider call issuing or voiding tickets
+// A call still running when the lock expires could let a retry ticket twice.
+const ticketingTimeout = time.Minute
+
+// BookingService implements the reservation use cases
+type BookingService struct {
+	Provider  interfaces.BookingProvider   // Provider holding the reservations; nil when unsupported
+	Ticketing interfaces.TicketingProvider // Provider issuing tickets; nil when unsupported
+	Offers    interfaces.OfferStore        // Offers from recent searches, for booking by ID; nil when unsupported
+	Locks     interfaces.LockStore         // Serializes ticketing of a reservation across instances; nil relies on the reservation alone
+}
+
+// NewBookingService creates and initializes a new BookingService instance
+// Args:
+//
+//	provider - The provider reservations are created with, or nil
+//	ticketing - The provider tickets are issued with, or nil
+//	offers - The store of offers from recent searches, or nil
+//	locks - The store serializing ticketing operations, or nil
+//
+// Returns:
+//
+//	Pointer to a new BookingService instance
+func NewBookingService(provider interfaces.BookingProvider, ticketing interfaces.TicketingProvider, offers interfaces.OfferStore, locks interfaces.LockStore) *BookingService {
+	return &BookingService{
+		Provider:  provider,
+		Ticketing: ticketing,
+		Offers:    offers,
+		Locks:     locks,
+	}
+}
+
+// CreateBooking creates a held reservation for the selected itinerary
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The validated booking request
+//
+// Returns:
+//
+//	Pointer to BookingResponse with the record locator or an error if booking fails
+func (s *BookingService) CreateBooking(ctx context.Context, req *DTO.BookingRequest) (*DTO.BookingResponse, error) {
+	if s.Provider == nil {
+		return nil, domain.ErrNotSupported
+	}
+
+	// A booking by itinerary ID takes the flights and passengers of the offer
+	if req.OfferID != "" {
+		snapshot, err := loadSnapshot(ctx, s.Offers, req.OfferID)
+		if err != nil {
+			return nil, err
+		}
+		// Reservations are retrieved and ticketed through the booking provider, so
+		// only the offers it found can be booked by ID
+		if source := snapshot.Offer.Source; source != s.Provider.Name() {
+			return nil, fmt.Errorf("%w: booking offers from %s", domain.ErrNotSupported, source)
+		}
+		if err := req.ApplyOffer(snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	booking, err := s.Provider.CreateBooking(ctx, req.ToBookingRequest())
+	if err != nil {
+		return nil, err
+	}
+	return &DTO.BookingResponse{Booking: booking}, nil
+}
+
+// GetBooking retrieves a reservation by its record locator
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	locator - Record locator of the reservation
+//
+// Returns:
+//
+//	Pointer to BookingResponse with the current reservation or an error if retrieval fails
+func (s *BookingService) GetBooking(ctx context.Context, locator string) (*DTO.BookingResponse, error) {
+	if s.Provider == nil {
+		return nil, domain.ErrNotSupported
+	}
+
+	booking, err := s.Provider.GetBooking(ctx, locator)
+	if err != nil {
+		return nil, err
+	}
+	return &DTO.BookingResponse{Booking: booking}, nil
+}
+
+// CancelBooking cancels the requested segments, or the whole reservation
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The validated cancellation request
+//
+// Returns:
+//
+//	Pointer to BookingResponse with the updated reservation or an error if cancellation fails
+func (s *BookingService) CancelBooking(ctx context.Context, req *DTO.CancelBookingRequest) (*DTO.BookingResponse, error) {
+	if s.Provider == nil {
+		return nil, domain.ErrNotSupported
+	}
+
+	booking, err := s.Provider.CancelBooking(ctx, req.RecordLocator, req.Segments)
+	if err != nil {
+		return nil, err
+	}
+	return &DTO.BookingResponse{Booking: booking}, nil
+}
+
+// IssueTickets issues the tickets of a held reservation
+// The call is idempotent: the reservation is the record of what was issued, so
+// one whose travelers all hold issued tickets is returned as is, even after a
+// restart, and a partly ticketed one only tickets the travelers left.
+// Concurrent calls for the same reservation are rejected while one holds its
+// lock, so a retried request never tickets twice. Tickets are issued on the
+// validating carrier of the price quote stored with the reservation.
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The validated ticketing request
+//
+// Returns:
+//
+//	Pointer to BookingResponse with the ticketed reservation or an error if ticketing fails
+func (s *BookingService) IssueTickets(ctx context.Context, req *DTO.TicketRequest) (*DTO.BookingResponse, error) {
+	if s.Provider == nil || s.Ticketing == nil {
+		return nil, domain.ErrNotSupported
+	}
+
+	unlock, err := s.lock(ctx, req.RecordLocator)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	booking, err := s.Provider.GetBooking(ctx, req.RecordLocator)
+	if err != nil {
+		return nil, err
+	}
+	ticketing := req.ToTicketingRequest(booking.ValidatingCarrier)
+	if len(booking.IssuedTickets()) > 0 {
+		ticketing.Travelers = booking.UnticketedTravelers()
+		if len(ticketing.Travelers) == 0 {
+			return &DTO.BookingResponse{Booking: booking}, nil
+		}
+	}
+	// A partly ticketed reservation reads as ticketed and is finished like a held one
+	if booking.Status != domain.BookingHeld && (booking.Status != domain.BookingTicketed || len(ticketing.Travelers) == 0) {
+		return nil, fmt.Errorf("%w: cannot ticket a %s booking", domain.ErrBookingState, booking.Status)
+	}
+	if booking.ValidatingCarrier == "" {
+		return nil, fmt.Errorf("%w: booking %s has no stored price quote to ticket", domain.ErrBookingState, req.RecordLocator)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, ticketingTimeout)
+	defer cancel()
+	tickets, err := s.Ticketing.IssueTickets(callCtx, ticketing)
+	if err != nil {
+		return nil, err
+	}
+	booking.Tickets = append(booking.Tickets, tickets...)
+	booking.Status = domain.BookingTicketed
+	return &DTO.BookingResponse{Booking: booking}, nil
+}
+
+// VoidTickets voids every issued ticket of a reservation within the void window
+// Tickets can only be voided on the day they were issued in the ticketing time
+// zone; a ticket whose issue date is unknown is taken to be outside the window.
+// Calling it again once the tickets are voided returns the reservation unchanged.
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	locator - Record locator of the reservation
+//
+// Returns:
+//
+//	Pointer to BookingResponse with the updated reservation or an error if voiding fails
+func (s *BookingService) VoidTickets(ctx context.Context, locator string) (*DTO.BookingResponse, error) {
+	if s.Provider == nil || s.Ticketing == nil {
+		return nil, domain.ErrNotSupported
+	}
+
+	unlock, err := s.lock(ctx, locator)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	booking, err := s.Provider.GetBooking(ctx, locator)
+	if err != nil {
+		return nil, err
+	}
+	issued := booking.IssuedTickets()
+	if len(issued) == 0 {
+		if len(booking.Tickets) > 0 {
+			return &DTO.BookingResponse{Booking: booking}, nil
+		}
+		return nil, fmt.Errorf("%w: booking %s has no tickets to void", domain.ErrBookingState, locator)
+	}
+
+	today := time.Now().In(s.Ticketing.TicketingTimeZone()).Format("2006-01-02")
+	numbers := make([]string, 0, len(issued))
+	for _, t := range issued {
+		if t.IssueDate == "" {
+			return nil, fmt.Errorf("%w: ticket %s has no known issue date", domain.ErrVoidWindowClosed, t.Number)
+		}
+		if t.IssueDate != today {
+			return nil, fmt.Errorf("%w: ticket %s was issued on %s", domain.ErrVoidWindowClosed, t.Number, t.IssueDate)
+		}
+		numbers = append(numbers, t.Number)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, ticketingTimeout)
+	defer cancel()
+	if err := s.Ticketing.VoidTickets(callCtx, locator, numbers); err != nil {
+		return nil, err
+	}
+	for i := range booking.Tickets {
+		if booking.Tickets[i].Status == domain.TicketIssued {
+			booking.Tickets[i].Status = domain.TicketVoided
+		}
+	}
+	booking.Status = domain.BookingHeld
+	return &DTO.BookingResponse{Booking: booking}, nil
+}
+
+// lock takes the ticketing lock of a reservation and returns the unlock function
+// Returns domain.ErrBookingState while another ticketing operation holds it.
+func (s *BookingService) lock(ctx context.Context, locator string) (func(), error) {
+	if s.Locks == nil {
+		return func() {}, nil
+	}
+	key := "ticketing:" + locator
+	token, ok, err := s.Locks.Acquire(ctx, key, ticketingLockTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock booking %s: %w", locator, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: ticketing of booking %s is already in progress", domain.ErrBookingState, locator)
+	}
+	// The lock is released even when the request was cancelled meanwhile
+	return func() { s.Locks.Release(context.WithoutCancel(ctx), key, token) }, nil
+}
@@ -0,0 +1,268 @@
+package use_case
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+	"github.com/Yordi-SE/FlightSearch/providers/locks"
+	"github.com/Yordi-SE/FlightSearch/providers/snapshots"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// stubReservations is a booking and ticketing provider holding one reservation
+type stubReservations struct {
+	booking *domain.Booking
+	zone    *time.Location
+	created []*domain.BookingRequest
+	issued  []*domain.TicketingRequest
+	voided  []string
+	open    bool // A ticketing call ran without a deadline
+}
+
+func (p *stubReservations) Name() string {
+	return "sabre:A"
+}
+
+func (p *stubReservations) CreateBooking(ctx context.Context, req *domain.BookingRequest) (*domain.Booking, error) {
+	p.created = append(p.created, req)
+	return &domain.Booking{RecordLocator: "ABCDEF", Status: domain.BookingHeld}, nil
+}
+
+func (p *stubReservations) GetBooking(ctx context.Context, locator string) (*domain.Booking, error) {
+	if p.booking == nil || p.booking.RecordLocator != locator {
+		return nil, domain.ErrBookingNotFound
+	}
+	booking := *p.booking
+	booking.Tickets = append([]domain.Ticket(nil), p.booking.Tickets...)
+	return &booking, nil
+}
+
+func (p *stubReservations) CancelBooking(ctx context.Context, locator string, segmentIDs []string) (*domain.Booking, error) {
+	return nil, domain.ErrNotSupported
+}
+
+func (p *stubReservations) IssueTickets(ctx context.Context, req *domain.TicketingRequest) ([]domain.Ticket, error) {
+	p.issued = append(p.issued, req)
+	_, deadline := ctx.Deadline()
+	p.open = p.open || !deadline
+	return []domain.Ticket{{Number: "0011234567890", Status: domain.TicketIssued}}, nil
+}
+
+func (p *stubReservations) VoidTickets(ctx context.Context, locator string, numbers []string) error {
+	p.voided = append(p.voided, numbers...)
+	_, deadline := ctx.Deadline()
+	p.open = p.open || !deadline
+	return nil
+}
+
+func (p *stubReservations) TicketingTimeZone() *time.Location {
+	return p.zone
+}
+
+func TestCreateBookingFromOffer(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr error
+	}{
+		{name: "offer found by the booking provider", source: "sabre:A"},
+		{name: "offer found under another PCC", source: "sabre:B", wantErr: domain.ErrNotSupported},
+		{name: "offer found by another provider", source: "amadeus", wantErr: domain.ErrNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offers := snapshots.NewMemoryStore(time.Minute)
+			offer := testOffer(tt.source, "DL", 400, 300, "USD")
+			offer.Legs[0].Segments[0].BookingClass = "V"
+			offer.ItineraryID = "0123456789abcdef0123"
+			snapshot := &domain.OfferSnapshot{Offer: offer, Passengers: []domain.PassengerCount{{Type: domain.PassengerAdult, Count: 1}}}
+			if err := offers.Save(context.Background(), snapshot); err != nil {
+				t.Fatalf("saving offer: %v", err)
+			}
+			provider := &stubReservations{zone: time.UTC}
+			service := NewBookingService(provider, provider, offers, locks.NewMemoryStore())
+
+			_, err := service.CreateBooking(context.Background(), &DTO.BookingRequest{
+				OfferID: offer.ItineraryID,
+				Travelers: []DTO.TravelerDetails{
+					{Type: domain.PassengerAdult, GivenName: "Ada", Surname: "Lovelace", DateOfBirth: "1985-12-10", Gender: "F"},
+				},
+				Contact: DTO.ContactDetails{Email: "ada@example.com", Phone: "+15555550100"},
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if len(provider.created) != 0 {
+					t.Error("offer from another source was booked")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(provider.created) != 1 {
+				t.Fatalf("expected one booking, got %d", len(provider.created))
+			}
+		})
+	}
+}
+
+func TestIssueTickets(t *testing.T) {
+	cash := DTO.FormOfPaymentDetails{Type: domain.PaymentTypeCash}
+	travelers := []domain.Traveler{
+		{Type: domain.PassengerAdult, GivenName: "Ada", Surname: "Lovelace"},
+		{Type: domain.PassengerAdult, GivenName: "Charles", Surname: "Babbage"},
+	}
+	tests := []struct {
+		name          string
+		booking       domain.Booking
+		locked        bool // Another ticketing operation holds the reservation
+		wantErr       error
+		wantIssued    bool
+		wantTravelers []int
+	}{
+		{
+			name:       "tickets on the stored validating carrier",
+			booking:    domain.Booking{RecordLocator: "ABCDEF", Status: domain.BookingHeld, ValidatingCarrier: "AA"},
+			wantIssued: true,
+		},
+		{
+			name: "already ticketed is returned as is",
+			booking: domain.Booking{RecordLocator: "ABCDEF", Status: domain.BookingTicketed, ValidatingCarrier: "AA",
+				Tickets: []domain.Ticket{{Number: "0011234567890", Status: domain.TicketIssued}}},
+		},
+		{
+			name: "every traveler ticketed is returned as is",
+			booking: domain.Booking{RecordLocator: "ABCDEF", Status: domain.BookingTicketed, ValidatingCarrier: "AA", Travelers: travelers,
+				Tickets: []domain.Ticket{
+					{Number: "0011234567890", Traveler: "Charles Babbage", Status: domain.TicketIssued},
+					{Number: "0011234567891", Traveler: "Ada Lovelace", Status: domain.TicketIssued},
+				}},
+		},
+		{
+			name: "partly ticketed tickets the travelers left",
+			booking: domain.Booking{RecordLocator: "ABCDEF", Status: domain.BookingTicketed, ValidatingCarrier: "AA", Travelers: travelers,
+				Tickets: []domain.Ticket{
+					{Number: "0011234567890", Traveler: "Ada Lovelace", Status: domain.TicketIssued},
+					{Number: "0011234567891", Traveler: "Charles Babbage", Status: domain.TicketVoided},
+				}},
+			wantIssued:    true,
+			wantTravelers: []int{2},
+		},
+		{
+			name:    "no stored price quote",
+			booking: domain.Booking{RecordLocator: "ABCDEF", Status: domain.BookingHeld},
+			wantErr: domain.ErrBookingState,
+		},
+		{
+			name:    "cancelled booking",
+			booking: domain.Booking{RecordLocator: "ABCDEF", Status: domain.BookingCancelled, ValidatingCarrier: "AA"},
+			wantErr: domain.ErrBookingState,
+		},
+		{
+			name:    "ticketing in progress elsewhere",
+			booking: domain.Booking{RecordLocator: "ABCDEF", Status: domain.BookingHeld, ValidatingCarrier: "AA"},
+			locked:  true,
+			wantErr: domain.ErrBookingState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &stubReservations{booking: &tt.booking, zone: time.UTC}
+			store := locks.NewMemoryStore()
+			if tt.locked {
+				if _, ok, _ := store.Acquire(context.Background(), "ticketing:ABCDEF", time.Minute); !ok {
+					t.Fatal("could not take the lock")
+				}
+			}
+			service := NewBookingService(provider, provider, nil, store)
+
+			resp, err := service.IssueTickets(context.Background(), &DTO.TicketRequest{RecordLocator: "ABCDEF", Payment: cash})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(provider.issued) > 0; got != tt.wantIssued {
+				t.Fatalf("issued = %v, want %v", got, tt.wantIssued)
+			}
+			if tt.wantIssued {
+				if provider.issued[0].ValidatingCarrier != "AA" {
+					t.Errorf("validating carrier = %q, want AA", provider.issued[0].ValidatingCarrier)
+				}
+				if got := fmt.Sprint(provider.issued[0].Travelers); got != fmt.Sprint(tt.wantTravelers) {
+					t.Errorf("ticketed travelers %s, want %s", got, fmt.Sprint(tt.wantTravelers))
+				}
+				if provider.open {
+					t.Error("tickets were issued without a deadline")
+				}
+			}
+			if resp.Booking.Status != domain.BookingTicketed {
+				t.Errorf("status = %q, want %q", resp.Booking.Status, domain.BookingTicketed)
+			}
+
+			// The lock is released, so a retry reaches the reservation again
+			if _, ok, _ := store.Acquire(context.Background(), "ticketing:ABCDEF", time.Minute); !ok {
+				t.Error("lock was not released")
+			}
+		})
+	}
+}
+
+func TestVoidTicketsWindow(t *testing.T) {
+	// The void window follows the ticketing time zone rather than the server's
+	tokyo := time.FixedZone("JST", 9*60*60)
+	todayInTokyo := time.Now().In(tokyo).Format("2006-01-02")
+	todayInUTC := time.Now().UTC().Format("2006-01-02")
+
+	tests := []struct {
+		name      string
+		zone      *time.Location
+		issueDate string
+		wantErr   error
+	}{
+		{name: "issued today in the ticketing time zone", zone: tokyo, issueDate: todayInTokyo},
+		{name: "issued yesterday", zone: tokyo, issueDate: time.Now().In(tokyo).AddDate(0, 0, -1).Format("2006-01-02"), wantErr: domain.ErrVoidWindowClosed},
+		{name: "issued today in UTC", zone: time.UTC, issueDate: todayInUTC},
+		{name: "issue date unknown", zone: time.UTC, wantErr: domain.ErrVoidWindowClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &stubReservations{zone: tt.zone, booking: &domain.Booking{
+				RecordLocator: "ABCDEF",
+				Status:        domain.BookingTicketed,
+				Tickets:       []domain.Ticket{{Number: "0011234567890", IssueDate: tt.issueDate, Status: domain.TicketIssued}},
+			}}
+			service := NewBookingService(provider, provider, nil, locks.NewMemoryStore())
+
+			resp, err := service.VoidTickets(context.Background(), "ABCDEF")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(provider.voided) != 1 || resp.Booking.Tickets[0].Status != domain.TicketVoided {
+				t.Errorf("tickets not voided: %+v", resp.Booking.Tickets)
+			}
+			if provider.open {
+				t.Error("tickets were voided without a deadline")
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package use_case
+
+import (
+	"context"
+	"fmt"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// CurrencyService converts prices into the currency a shopper asked for
+type CurrencyService struct {
+	Source interfaces.RateSource // Where exchange rates come from
+}
+
+// NewCurrencyService creates and initializes a new CurrencyService instance
+// Args:
+//
+//	source - The exchange rate source
+//
+// Returns:
+//
+//	Pointer to a new CurrencyService instance
+func NewCurrencyService(source interfaces.RateSource) *CurrencyService {
+	return &CurrencyService{
+		Source: source,
+	}
+}
+
+// ConvertOffers converts every offer into the target currency
+// Offers whose currency has no known rate are dropped and reported as warnings.
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	offers - The offers to convert
+//	to - ISO 4217 code of the target currency
+//
+// Returns:
+//
+//	The converted offers, warnings for dropped offers, or an error if the target is unsupported
+func (s *CurrencyService) ConvertOffers(ctx context.Context, offers []domain.Offer, to string) ([]domain.Offer, []domain.Warning, error) {
+	table, err := s.Source.Rates(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !table.Supports(to) {
+		return nil, nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, to)
+	}
+
+	converted := make([]domain.Offer, 0, len(offers))
+	var warnings []domain.Warning
+	for _, offer := range offers {
+		rate, err := table.Rate(offer.Price.Currency, to)
+		if err != nil {
+			warnings = append(warnings, domain.Warning{
+				Code:    domain.WarningOfferNotConverted,
+				Message: err.Error(),
+				Source:  offer.Source,
+				OfferID: offer.ID,
+			})
+			continue
+		}
+
+		offer.Price = convertPrice(offer.Price, rate, to, table.Date)
+		if len(offer.FareFamilies) > 0 {
+			families := make([]domain.FareFamily, len(offer.FareFamilies))
+			for i, family := range offer.FareFamilies {
+				family.Price = convertPrice(family.Price, rate, to, table.Date)
+				families[i] = family
+			}
+			// Differences are taken from the converted prices so they match what is shown
+			for i := range families {
+				families[i].PriceDifference = domain.RoundAmount(families[i].Price.Total-families[0].Price.Total, to)
+			}
+			offer.FareFamilies = families
+		}
+		if len(offer.PassengerFares) > 0 {
+			offer.PassengerFares = convertPassengerFares(offer.PassengerFares, table, to)
+		}
+		if len(offer.BaggagePolicies) > 0 {
+			offer.BaggagePolicies = convertBaggagePolicies(offer.BaggagePolicies, table, to)
+		}
+		if bags := offer.PriceWithBags; bags != nil && bags.Currency != to {
+			fees := domain.RoundAmount(bags.BaggageFees*rate, to)
+			offer.PriceWithBags = &domain.BaggagePrice{
+				CheckedBags: bags.CheckedBags,
+				BaggageFees: fees,
+				Total:       domain.RoundAmount(offer.Price.Total+fees, to),
+				Currency:    to,
+				Complete:    bags.Complete,
+			}
+		}
+		converted = append(converted, offer)
+	}
+	return converted, warnings, nil
+}
+
+// convertPrice converts a price at the given rate, rounding to the currency's minor unit
+// The total, taxes and markup are converted, and the net fare and base are derived
+// from them so the parts still add up to the total after rounding. Parts that did
+// not add up in the provider's currency are converted on their own.
+func convertPrice(price domain.Price, rate float64, to, rateDate string) domain.Price {
+	if price.Currency == to {
+		return price
+	}
+	converted := domain.Price{
+		Total:    domain.RoundAmount(price.Total*rate, to),
+		Taxes:    domain.RoundAmount(price.Taxes*rate, to),
+		Markup:   domain.RoundAmount(price.Markup*rate, to),
+		Currency: to,
+		Conversion: &domain.Conversion{
+			OriginalTotal:    price.Total,
+			OriginalCurrency: price.Currency,
+			Rate:             rate,
+			RateDate:         rateDate,
+		},
+	}
+
+	net := price.Total - price.Markup
+	convertedNet := domain.RoundAmount(converted.Total-converted.Markup, to)
+	if price.Net != 0 {
+		converted.Net = domain.RoundAmount(price.Net*rate, to)
+		if addsUp(price.Net, net, price.Currency) {
+			converted.Net = convertedNet
+		}
+	}
+	converted.Base = domain.RoundAmount(price.Base*rate, to)
+	if addsUp(price.Base+price.Taxes, net, price.Currency) {
+		converted.Base = domain.RoundAmount(convertedNet-converted.Taxes, to)
+	}
+	return converted
+}
+
+// addsUp reports whether two amounts are equal to the currency's minor unit
+func addsUp(a, b float64, currency string) bool {
+	return domain.RoundAmount(a-b, currency) == 0
+}
+
+// convertPassengerFares converts itemized passenger fares and their taxes
+// A tax in a currency without a known rate is kept in its own currency.
+func convertPassengerFares(fares []domain.PassengerFare, table *domain.RateTable, to string) []domain.PassengerFare {
+	converted := make([]domain.PassengerFare, len(fares))
+	for i, fare := range fares {
+		if rate, err := table.Rate(fare.Currency, to); err == nil {
+			// The base is derived like in convertPrice so the fare still adds up
+			base := domain.RoundAmount(fare.Base*rate, to)
+			total := domain.RoundAmount(fare.Total*rate, to)
+			totalTaxes := domain.RoundAmount(fare.TotalTaxes*rate, to)
+			if addsUp(fare.Base+fare.TotalTaxes, fare.Total, fare.Currency) {
+				base = domain.RoundAmount(total-totalTaxes, to)
+			}
+			fare.Base, fare.TotalTaxes, fare.Total, fare.Currency = base, totalTaxes, total, to
+		}
+		taxes := make([]domain.Tax, len(fare.Taxes))
+		for j, tax := range fare.Taxes {
+			if rate, err := table.Rate(tax.Currency, to); err == nil {
+				tax.Amount = domain.RoundAmount(tax.Amount*rate, to)
+				tax.Currency = to
+			}
+			taxes[j] = tax
+		}
+		fare.Taxes = taxes
+		converted[i] = fare
+	}
+	return converted
+}
+
+// convertBaggagePolicies converts the bag fees of baggage policies
+// A fee in a currency without a known rate is kept in its own currency.
+func convertBaggagePolicies(policies []domain.BaggagePolicy, table *domain.RateTable, to string) []domain.BaggagePolicy {
+	converted := make([]domain.BaggagePolicy, len(policies))
+	for i, policy := range policies {
+		policy.FirstBagFee = convertMoney(policy.FirstBagFee, table, to)
+		policy.SecondBagFee = convertMoney(policy.SecondBagFee, table, to)
+		converted[i] = policy
+	}
+	return converted
+}
+
+// convertMoney returns a converted copy of an optional amount
+func convertMoney(money *domain.Money, table *domain.RateTable, to string) *domain.Money {
+	if money == nil {
+		return nil
+	}
+	rate, err := table.Rate(money.Currency, to)
+	if err != nil {
+		return money
+	}
+	return &domain.Money{Amount: domain.RoundAmount(money.Amount*rate, to), Currency: to}
+}
@@ -0,0 +1,120 @@
+package use_case
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// stubRates is a RateSource serving a fixed table
+type stubRates struct {
+	table *domain.RateTable
+}
+
+func (s stubRates) Rates(ctx context.Context) (*domain.RateTable, error) {
+	return s.table, nil
+}
+
+func TestConvertPrice(t *testing.T) {
+	tests := []struct {
+		name  string
+		price domain.Price
+		rate  float64
+		to    string
+		want  domain.Price
+	}{
+		{
+			name:  "parts add up after rounding",
+			price: domain.Price{Total: 100.01, Base: 50.005, Taxes: 50.005, Currency: "USD"},
+			rate:  1,
+			to:    "EUR",
+			want:  domain.Price{Total: 100.01, Base: 50, Taxes: 50.01, Currency: "EUR"},
+		},
+		{
+			name:  "no decimals",
+			price: domain.Price{Total: 201.11, Base: 100.55, Taxes: 100.56, Currency: "USD"},
+			rate:  150.5,
+			to:    "JPY",
+			want:  domain.Price{Total: 30267, Base: 15133, Taxes: 15134, Currency: "JPY"},
+		},
+		{
+			name:  "three decimals",
+			price: domain.Price{Total: 333.33, Base: 222.22, Taxes: 111.11, Currency: "USD"},
+			rate:  0.30712,
+			to:    "KWD",
+			want:  domain.Price{Total: 102.372, Base: 68.248, Taxes: 34.124, Currency: "KWD"},
+		},
+		{
+			name:  "from three decimals",
+			price: domain.Price{Total: 100.001, Base: 60.0005, Taxes: 40.0005, Currency: "KWD"},
+			rate:  3.2561,
+			to:    "USD",
+			want:  domain.Price{Total: 325.61, Base: 195.36, Taxes: 130.25, Currency: "USD"},
+		},
+		{
+			name:  "marked up",
+			price: domain.Price{Total: 215.5, Base: 150.25, Taxes: 50.25, Net: 200.5, Markup: 15, Currency: "USD"},
+			rate:  150.5,
+			to:    "JPY",
+			want:  domain.Price{Total: 32433, Base: 22612, Taxes: 7563, Net: 30175, Markup: 2258, Currency: "JPY"},
+		},
+		{
+			name:  "parts that did not add up are converted on their own",
+			price: domain.Price{Total: 110, Base: 80, Taxes: 20, Currency: "USD"},
+			rate:  150.5,
+			to:    "JPY",
+			want:  domain.Price{Total: 16555, Base: 12040, Taxes: 3010, Currency: "JPY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertPrice(tt.price, tt.rate, tt.to, "2025-06-01")
+			if got.Conversion == nil || got.Conversion.OriginalTotal != tt.price.Total || got.Conversion.Rate != tt.rate {
+				t.Errorf("conversion = %+v", got.Conversion)
+			}
+			got.Conversion = nil
+			if got != tt.want {
+				t.Errorf("convertPrice() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertOffersRoundsToMinorUnit(t *testing.T) {
+	table := &domain.RateTable{Base: "USD", Date: "2025-06-01", Rates: map[string]float64{"JPY": 157.123, "KWD": 0.30712}}
+	service := NewCurrencyService(stubRates{table: table})
+
+	tests := []struct {
+		to     string
+		digits int
+	}{
+		{to: "JPY", digits: 0},
+		{to: "KWD", digits: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.to, func(t *testing.T) {
+			offers := []domain.Offer{
+				{ID: "1", Price: domain.Price{Total: 279.77, Base: 228, Taxes: 51.77, Currency: "USD"}},
+				{ID: "2", Price: domain.Price{Total: 100, Base: 80, Taxes: 20, Currency: "GBP"}},
+			}
+			converted, warnings, err := service.ConvertOffers(context.Background(), offers, tt.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(converted) != 1 || len(warnings) != 1 || warnings[0].OfferID != "2" {
+				t.Fatalf("expected the GBP offer to be dropped, got %d offers and warnings %+v", len(converted), warnings)
+			}
+			price := converted[0].Price
+			for _, amount := range []float64{price.Total, price.Base, price.Taxes} {
+				if domain.RoundAmount(amount, tt.to) != amount {
+					t.Errorf("%v has more than %d decimals", amount, tt.digits)
+				}
+			}
+			if domain.RoundAmount(price.Base+price.Taxes, tt.to) != price.Total {
+				t.Errorf("base %v and taxes %v do not add up to %v", price.Base, price.Taxes, price.Total)
+			}
+		})
+	}
+}
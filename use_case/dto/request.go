@@ -1,6 +1,13 @@
 package DTO
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
 
 type Passenger struct {
 	Type  string `json:"type" binding:"required,oneof=ADT CNN INF C06"` // Adult, Child, Infant
@@ -8,117 +15,700 @@ type Passenger struct {
 }
 
 type FlightSearchRequest struct {
-	TripType          string      `json:"trip_type" binding:"required,oneof=one_way round_trip"`
-	Origin            string      `json:"origin" binding:"required,len=3"`      // IATA code
-	Destination       string      `json:"destination" binding:"required,len=3"` // IATA code
-	DepartureDateTime string      `json:"departure_date" binding:"required"`    // Format: YYYY-MM-DD
-	ReturnDateTime    string      `json:"return_date"`                          // Required for round_trip
-	Passengers        []Passenger `json:"passengers" binding:"required,dive"`
+	TripType          string         `json:"trip_type" binding:"required,oneof=one_way round_trip"`
+	Origin            string         `json:"origin" binding:"required,max=64"`                        // IATA airport or city code, or comma-separated airports
+	Destination       string         `json:"destination" binding:"required,max=64"`                   // IATA airport or city code, or comma-separated airports
+	OriginType        string         `json:"origin_type" binding:"omitempty,oneof=airport city"`      // Searches a code naming both an airport and a city (e.g. SHA) as the city; airport when empty
+	DestinationType   string         `json:"destination_type" binding:"omitempty,oneof=airport city"` // As OriginType
+	DepartureDateTime string         `json:"departure_date" binding:"required"`                       // Format: YYYY-MM-DD
+	ReturnDateTime    string         `json:"return_date"`                                             // Required for round_trip
+	Passengers        []Passenger    `json:"passengers" binding:"required,dive"`
+	Currency          string         `json:"currency" binding:"omitempty,len=3,uppercase"` // ISO 4217 display currency; provider currency when empty
+	Channel           string         `json:"channel" binding:"omitempty,max=32"`           // Sales channel used to select markup rules
+	Filters           *SearchFilters `json:"filters"`
+	CheckedBags       int            `json:"checked_bags" binding:"omitempty,min=1,max=2"`      // Prices each offer with this many checked bags per passenger and leg
+	SortBy            string         `json:"sort_by" binding:"omitempty,oneof=price emissions"` // Order of the results; price when empty
+}
+
+// Orders the search results can be sorted in
+const (
+	SortByPrice     = "price"
+	SortByEmissions = "emissions"
+)
+
+// SearchFilters narrows down the offers returned by a search
+// Connection limits apply to every connection of every leg. Providers are asked
+// for matching itineraries where they support it, and the offers are filtered again.
+type SearchFilters struct {
+	MinConnectionMinutes int              `json:"min_connection_minutes" binding:"omitempty,min=0,max=1440"`
+	MaxConnectionMinutes int              `json:"max_connection_minutes" binding:"omitempty,min=0,max=2880"`
+	ExcludeOvernight     bool             `json:"exclude_overnight"`                   // Drop itineraries with a connection spanning midnight
+	ExcludeRedEye        bool             `json:"exclude_red_eye"`                     // Drop itineraries with a night flight landing in the morning
+	Legs                 []LegTimeWindows `json:"legs" binding:"omitempty,max=2,dive"` // Outbound, then return; one-way searches have one leg
+	MaxCO2Kg             float64          `json:"max_co2_kg" binding:"omitempty,gt=0"` // Drop itineraries emitting more CO2 per passenger
+}
+
+// LegTimeWindows restricts the local departure and arrival times of a leg
+// An after bound later than the before bound wraps past midnight, e.g. 22:00 to 02:00.
+type LegTimeWindows struct {
+	DepartureAfter  string `json:"departure_after"` // Format: HH:MM
+	DepartureBefore string `json:"departure_before"`
+	ArrivalAfter    string `json:"arrival_after"`
+	ArrivalBefore   string `json:"arrival_before"`
+}
+
+// clockPattern matches a HH:MM time of day
+var clockPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// Validate ensures the filters are consistent
+func (f *SearchFilters) Validate() error {
+	if f.MaxConnectionMinutes > 0 && f.MinConnectionMinutes > f.MaxConnectionMinutes {
+		return fmt.Errorf("filters: min_connection_minutes must not exceed max_connection_minutes")
+	}
+	for i, w := range f.Legs {
+		for _, bound := range []struct{ after, before, name string }{
+			{w.DepartureAfter, w.DepartureBefore, "departure"},
+			{w.ArrivalAfter, w.ArrivalBefore, "arrival"},
+		} {
+			for _, clock := range []string{bound.after, bound.before} {
+				if clock != "" && !clockPattern.MatchString(clock) {
+					return fmt.Errorf("filters: legs[%d] invalid %s time %q, expected HH:MM", i, bound.name, clock)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ToPreferences converts the filters into provider-neutral search preferences
+func (f *SearchFilters) ToPreferences() *domain.SearchPreferences {
+	prefs := &domain.SearchPreferences{
+		MinConnection:    f.MinConnectionMinutes,
+		MaxConnection:    f.MaxConnectionMinutes,
+		ExcludeOvernight: f.ExcludeOvernight,
+		ExcludeRedEye:    f.ExcludeRedEye,
+		MaxCO2Kg:         f.MaxCO2Kg,
+	}
+	for _, w := range f.Legs {
+		prefs.Windows = append(prefs.Windows, domain.TimeWindows{
+			DepartureAfter:  w.DepartureAfter,
+			DepartureBefore: w.DepartureBefore,
+			ArrivalAfter:    w.ArrivalAfter,
+			ArrivalBefore:   w.ArrivalBefore,
+		})
+	}
+	return prefs
 }
 
 // Validate ensures business rules (e.g., no child/infant traveling alone)
 func (r *FlightSearchRequest) Validate() error {
-	hasAdult := false
-	for _, p := range r.Passengers {
-		if p.Type == "ADT" {
-			hasAdult = true
-			break
+	if err := validatePassengers(r.Passengers); err != nil {
+		return err
+	}
+	if r.TripType == "round_trip" && r.ReturnDateTime == "" {
+		return fmt.Errorf("return_date is required for round_trip")
+	}
+	if r.Filters != nil {
+		if err := r.Filters.Validate(); err != nil {
+			return err
+		}
+		if len(r.Filters.Legs) > 1 && r.TripType != domain.TripRoundTrip {
+			return fmt.Errorf("filters: legs has one entry per leg, and a %s search has one leg", r.TripType)
+		}
+	}
+	if err := validateLocation("origin", r.Origin, r.OriginType); err != nil {
+		return err
+	}
+	return validateLocation("destination", r.Destination, r.DestinationType)
+}
+
+// validateLocation ensures a location field holds valid codes and a type only for a single code
+func validateLocation(field, value, locationType string) error {
+	codes, err := ParseLocationCodes(field, value)
+	if err != nil {
+		return err
+	}
+	if locationType != "" && len(codes) > 1 {
+		return fmt.Errorf("%s_type applies to a single %s code, not a list", field, field)
+	}
+	return nil
+}
+
+// ToQuery converts the API request into the provider-neutral search criteria
+// Origin and destination are taken as airports unless the request asks for a city;
+// the use case resolves city codes.
+func (r *FlightSearchRequest) ToQuery() *domain.SearchQuery {
+	origins, _ := ParseLocationCodes("origin", r.Origin)
+	destinations, _ := ParseLocationCodes("destination", r.Destination)
+	query := &domain.SearchQuery{
+		TripType:        r.TripType,
+		OriginType:      locationType(r.OriginType),
+		DestinationType: locationType(r.DestinationType),
+		DepartureDate:   r.DepartureDateTime,
+		ReturnDate:      r.ReturnDateTime,
+		Passengers:      toPassengerCounts(r.Passengers),
+	}
+	if len(origins) > 0 {
+		query.Origin = origins[0]
+	}
+	if len(origins) > 1 {
+		query.OriginAirports = origins
+	}
+	if len(destinations) > 0 {
+		query.Destination = destinations[0]
+	}
+	if len(destinations) > 1 {
+		query.DestinationAirports = destinations
+	}
+	if r.Filters != nil {
+		query.Preferences = r.Filters.ToPreferences()
+	}
+	return query
+}
+
+// locationType returns the requested location type, airport when none was given
+func locationType(requested string) string {
+	if requested == domain.LocationCity {
+		return domain.LocationCity
+	}
+	return domain.LocationAirport
+}
+
+// MaxLocationCodes is the largest number of airports accepted for an origin or destination
+const MaxLocationCodes = 6
+
+// locationCodePattern matches an IATA airport or city code
+var locationCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ParseLocationCodes splits a comma-separated list of IATA codes
+// Codes are upper-cased and duplicates are dropped.
+// Args:
+//
+//	field - Name of the request field, used in error messages
+//	value - A single code or a list such as "LHR,LGW,STN"
+//
+// Returns:
+//
+//	The codes in request order or an error if one of them is malformed
+func ParseLocationCodes(field, value string) ([]string, error) {
+	var codes []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		code := strings.ToUpper(strings.TrimSpace(part))
+		if !locationCodePattern.MatchString(code) {
+			return nil, fmt.Errorf("%s: invalid IATA code %q", field, part)
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	if len(codes) > MaxLocationCodes {
+		return nil, fmt.Errorf("%s: at most %d airports may be listed", field, MaxLocationCodes)
+	}
+	return codes, nil
+}
+
+// validatePassengers ensures children and infants travel with an adult
+// Infants travel on an adult's lap, so there may be at most one per adult.
+func validatePassengers(passengers []Passenger) error {
+	adults, infants := 0, 0
+	for _, p := range passengers {
+		switch p.Type {
+		case domain.PassengerAdult:
+			adults += p.Count
+		case domain.PassengerInfant:
+			infants += p.Count
 		}
 	}
-	if !hasAdult && len(r.Passengers) > 0 {
+	if adults == 0 && len(passengers) > 0 {
 		return fmt.Errorf("at least one adult (ADT) is required when traveling with children or infants")
 	}
-	if r.TripType == "round_trip" && r.ReturnDateTime == "" {
-		return fmt.Errorf("return_date is required for round_trip")
+	if infants > adults {
+		return fmt.Errorf("each infant (INF) must travel on the lap of a different adult")
+	}
+	return nil
+}
+
+// toPassengerCounts converts API passengers into domain passenger counts
+func toPassengerCounts(passengers []Passenger) []domain.PassengerCount {
+	counts := make([]domain.PassengerCount, 0, len(passengers))
+	for _, p := range passengers {
+		counts = append(counts, domain.PassengerCount{Type: p.Type, Count: p.Count})
+	}
+	return counts
+}
+
+// SelectedSegment is a flight of the itinerary picked from the search results
+type SelectedSegment struct {
+	MarketingCarrier  string `json:"marketing_carrier" binding:"required,len=2"`
+	FlightNumber      int    `json:"flight_number" binding:"required,min=1"`
+	OperatingCarrier  string `json:"operating_carrier"`
+	Origin            string `json:"origin" binding:"required,len=3"`        // IATA code
+	Destination       string `json:"destination" binding:"required,len=3"`   // IATA code
+	DepartureDateTime string `json:"departure_date_time" binding:"required"` // Format: YYYY-MM-DDTHH:MM:SS
+	ArrivalDateTime   string `json:"arrival_date_time" binding:"required"`   // Format: YYYY-MM-DDTHH:MM:SS
+	BookingClass      string `json:"booking_class" binding:"required,min=1,max=2"`
+}
+
+// SelectedLeg is one direction of the picked itinerary
+type SelectedLeg struct {
+	Segments []SelectedSegment `json:"segments" binding:"required,min=1,dive"`
+}
+
+// RevalidateRequest asks for the current price of a selected itinerary
+// The itinerary is either given in full or by the itinerary ID of a search result.
+type RevalidateRequest struct {
+	OfferID       string        `json:"offer_id"` // Itinerary ID from the search results
+	Legs          []SelectedLeg `json:"legs" binding:"omitempty,max=2,dive"`
+	Passengers    []Passenger   `json:"passengers" binding:"omitempty,dive"`
+	ExpectedTotal float64       `json:"expected_total"`                     // Total shown in the search results, if known
+	Currency      string        `json:"currency"`                           // Currency of ExpectedTotal
+	Channel       string        `json:"channel" binding:"omitempty,max=32"` // Sales channel the offer was shown on
+}
+
+// Validate ensures business rules (e.g., no child/infant traveling alone)
+func (r *RevalidateRequest) Validate() error {
+	if r.OfferID != "" {
+		if len(r.Legs) > 0 || len(r.Passengers) > 0 {
+			return fmt.Errorf("give either offer_id or legs and passengers, not both")
+		}
+		return ValidateItineraryID(r.OfferID)
+	}
+	if len(r.Legs) == 0 || len(r.Passengers) == 0 {
+		return fmt.Errorf("legs and passengers are required without offer_id")
+	}
+	return validatePassengers(r.Passengers)
+}
+
+// ToSelection converts the API request into the provider-neutral selection
+func (r *RevalidateRequest) ToSelection() *domain.ItinerarySelection {
+	return toSelection(r.Legs, r.Passengers)
+}
+
+// toSelection converts selected legs and passengers into a domain selection
+func toSelection(legs []SelectedLeg, passengers []Passenger) *domain.ItinerarySelection {
+	sel := &domain.ItinerarySelection{
+		Legs:       make([]domain.SelectedLeg, 0, len(legs)),
+		Passengers: toPassengerCounts(passengers),
+	}
+	for _, leg := range legs {
+		segments := make([]domain.SelectedSegment, 0, len(leg.Segments))
+		for _, seg := range leg.Segments {
+			segments = append(segments, domain.SelectedSegment{
+				MarketingCarrier:  seg.MarketingCarrier,
+				FlightNumber:      seg.FlightNumber,
+				OperatingCarrier:  seg.OperatingCarrier,
+				Origin:            seg.Origin,
+				Destination:       seg.Destination,
+				DepartureDateTime: seg.DepartureDateTime,
+				ArrivalDateTime:   seg.ArrivalDateTime,
+				BookingClass:      seg.BookingClass,
+			})
+		}
+		sel.Legs = append(sel.Legs, domain.SelectedLeg{Segments: segments})
+	}
+	return sel
+}
+
+// TravelDocumentDetails is the identity document a traveler flies with
+type TravelDocumentDetails struct {
+	Type           string `json:"type" binding:"required,oneof=P I"` // Passport or national ID
+	Number         string `json:"number" binding:"required,alphanum,max=20"`
+	IssuingCountry string `json:"issuing_country" binding:"required,len=2"` // ISO 3166 alpha-2
+	Nationality    string `json:"nationality" binding:"required,len=2"`     // ISO 3166 alpha-2
+	ExpiryDate     string `json:"expiry_date" binding:"required"`           // Format: YYYY-MM-DD
+}
+
+// TravelerDetails names a passenger on the booking
+type TravelerDetails struct {
+	Type        string                 `json:"type" binding:"required,oneof=ADT CNN INF C06"`
+	GivenName   string                 `json:"given_name" binding:"required,max=30"`
+	Surname     string                 `json:"surname" binding:"required,max=30"`
+	DateOfBirth string                 `json:"date_of_birth" binding:"required"` // Format: YYYY-MM-DD
+	Gender      string                 `json:"gender" binding:"required,oneof=M F"`
+	Document    *TravelDocumentDetails `json:"document"`
+}
+
+// ContactDetails is how the airline and agency reach the customer
+type ContactDetails struct {
+	Email string `json:"email" binding:"required,email"`
+	Phone string `json:"phone" binding:"required,min=6,max=20"`
+}
+
+// BookingRequest creates a reservation for a selected itinerary
+// The itinerary is either given in full or by the itinerary ID of a search result.
+type BookingRequest struct {
+	OfferID    string            `json:"offer_id"` // Itinerary ID from the search results
+	Legs       []SelectedLeg     `json:"legs" binding:"omitempty,max=2,dive"`
+	Passengers []Passenger       `json:"passengers" binding:"omitempty,dive"` // Passenger mix used for the search
+	Travelers  []TravelerDetails `json:"travelers" binding:"required,min=1,dive"`
+	Contact    ContactDetails    `json:"contact" binding:"required"`
+}
+
+// Age limits per passenger type, in completed years on the day of travel
+var passengerAges = map[string]struct{ min, max int }{
+	"ADT": {12, 150},
+	"CNN": {2, 11},
+	"C06": {6, 6},
+	"INF": {0, 1},
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z '\-]*$`)
+
+var recordLocatorPattern = regexp.MustCompile(`^[A-Z0-9]{6}$`)
+
+var itineraryIDPattern = regexp.MustCompile(fmt.Sprintf(`^[0-9a-f]{%d}$`, domain.ItineraryIDLength))
+
+// Validate ensures the travelers match the passengers that were searched and priced
+// With an offer_id the travelers are checked once the offer is applied.
+func (r *BookingRequest) Validate() error {
+	if r.OfferID != "" {
+		if len(r.Legs) > 0 || len(r.Passengers) > 0 {
+			return fmt.Errorf("give either offer_id or legs and passengers, not both")
+		}
+		return ValidateItineraryID(r.OfferID)
+	}
+	if len(r.Legs) == 0 || len(r.Passengers) == 0 {
+		return fmt.Errorf("legs and passengers are required without offer_id")
+	}
+	return r.validateTravelers()
+}
+
+// ApplyOffer takes the itinerary and passengers of the offer the request refers to
+// Returns an error wrapping domain.ErrOfferMismatch when the travelers do not fit the offer.
+func (r *BookingRequest) ApplyOffer(snapshot *domain.OfferSnapshot) error {
+	sel := snapshot.Selection()
+	r.Legs = make([]SelectedLeg, 0, len(sel.Legs))
+	for _, leg := range sel.Legs {
+		segments := make([]SelectedSegment, 0, len(leg.Segments))
+		for _, seg := range leg.Segments {
+			segments = append(segments, SelectedSegment{
+				MarketingCarrier:  seg.MarketingCarrier,
+				FlightNumber:      seg.FlightNumber,
+				OperatingCarrier:  seg.OperatingCarrier,
+				Origin:            seg.Origin,
+				Destination:       seg.Destination,
+				DepartureDateTime: seg.DepartureDateTime,
+				ArrivalDateTime:   seg.ArrivalDateTime,
+				BookingClass:      seg.BookingClass,
+			})
+		}
+		r.Legs = append(r.Legs, SelectedLeg{Segments: segments})
+	}
+	r.Passengers = make([]Passenger, 0, len(sel.Passengers))
+	for _, p := range sel.Passengers {
+		r.Passengers = append(r.Passengers, Passenger{Type: p.Type, Count: p.Count})
+	}
+	if err := r.validateTravelers(); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrOfferMismatch, err)
+	}
+	return nil
+}
+
+// validateTravelers checks the travelers against the passengers and travel dates
+func (r *BookingRequest) validateTravelers() error {
+	if err := validatePassengers(r.Passengers); err != nil {
+		return err
+	}
+
+	// Every searched passenger needs exactly one named traveler
+	expected := make(map[string]int)
+	for _, p := range r.Passengers {
+		expected[p.Type] += p.Count
+	}
+	named := make(map[string]int)
+	for _, t := range r.Travelers {
+		named[t.Type]++
+	}
+	for paxType, count := range expected {
+		if named[paxType] != count {
+			return fmt.Errorf("expected %d %s traveler(s) as searched, got %d", count, paxType, named[paxType])
+		}
+	}
+	for paxType, count := range named {
+		if expected[paxType] == 0 {
+			return fmt.Errorf("got %d %s traveler(s) but none were searched", count, paxType)
+		}
+	}
+	if named["INF"] > named["ADT"] {
+		return fmt.Errorf("each infant (INF) must travel on the lap of a different adult")
+	}
+
+	firstDeparture, lastDeparture, err := travelDates(r.Legs)
+	if err != nil {
+		return err
+	}
+	for i, t := range r.Travelers {
+		if !namePattern.MatchString(t.GivenName) || !namePattern.MatchString(t.Surname) {
+			return fmt.Errorf("traveler %d: names may only contain letters, spaces, hyphens and apostrophes", i+1)
+		}
+
+		// The passenger type must hold for the whole trip
+		traveler := domain.Traveler{DateOfBirth: t.DateOfBirth}
+		limits := passengerAges[t.Type]
+		for _, date := range []time.Time{firstDeparture, lastDeparture} {
+			age, err := traveler.AgeOn(date)
+			if err != nil {
+				return fmt.Errorf("traveler %d: %v", i+1, err)
+			}
+			if age < limits.min || age > limits.max {
+				return fmt.Errorf("traveler %d: age %d on %s does not match passenger type %s", i+1, age, date.Format("2006-01-02"), t.Type)
+			}
+		}
+
+		if t.Document != nil {
+			expiry, err := time.Parse("2006-01-02", t.Document.ExpiryDate)
+			if err != nil {
+				return fmt.Errorf("traveler %d: invalid document expiry_date %q, expected YYYY-MM-DD", i+1, t.Document.ExpiryDate)
+			}
+			if !expiry.After(lastDeparture) {
+				return fmt.Errorf("traveler %d: document expires before the end of the trip", i+1)
+			}
+		}
 	}
 	return nil
 }
 
-type RequestLocation struct {
-	LocationCode string `json:"LocationCode"`
-	LocationType string `json:"LocationType"`
+// ToBookingRequest converts the API request into the provider-neutral booking request
+func (r *BookingRequest) ToBookingRequest() *domain.BookingRequest {
+	travelers := make([]domain.Traveler, 0, len(r.Travelers))
+	for _, t := range r.Travelers {
+		traveler := domain.Traveler{
+			Type:        t.Type,
+			GivenName:   t.GivenName,
+			Surname:     t.Surname,
+			DateOfBirth: t.DateOfBirth,
+			Gender:      t.Gender,
+		}
+		if t.Document != nil {
+			traveler.Document = &domain.TravelDocument{
+				Type:           t.Document.Type,
+				Number:         t.Document.Number,
+				IssuingCountry: t.Document.IssuingCountry,
+				Nationality:    t.Document.Nationality,
+				ExpiryDate:     t.Document.ExpiryDate,
+			}
+		}
+		travelers = append(travelers, traveler)
+	}
+	return &domain.BookingRequest{
+		Itinerary: *toSelection(r.Legs, r.Passengers),
+		Travelers: travelers,
+		Contact:   domain.Contact{Email: r.Contact.Email, Phone: r.Contact.Phone},
+	}
 }
 
-type OriginDest struct {
-	OriginLocation      RequestLocation `json:"OriginLocation"`
-	DestinationLocation RequestLocation `json:"DestinationLocation"`
-	DepartureDateTime   string          `json:"DepartureDateTime"`
+// travelDates returns the dates of the first and last departures of the selected legs
+func travelDates(legs []SelectedLeg) (time.Time, time.Time, error) {
+	var first, last time.Time
+	for _, leg := range legs {
+		for _, seg := range leg.Segments {
+			if len(seg.DepartureDateTime) < 10 {
+				return first, last, fmt.Errorf("invalid departure_date_time %q", seg.DepartureDateTime)
+			}
+			date, err := time.Parse("2006-01-02", seg.DepartureDateTime[:10])
+			if err != nil {
+				return first, last, fmt.Errorf("invalid departure_date_time %q", seg.DepartureDateTime)
+			}
+			if first.IsZero() || date.Before(first) {
+				first = date
+			}
+			if date.After(last) {
+				last = date
+			}
+		}
+	}
+	return first, last, nil
 }
 
-type OTA_AirLowFareSearchRQ struct {
-	Version                      string              `json:"Version"`
-	POS                          POS                 `json:"POS"`
-	OriginDestinationInformation []OriginDest        `json:"OriginDestinationInformation"`
-	TravelPreferences            TravelPreferences   `json:"TravelPreferences"`
-	TravelerInfoSummary          TravelerInfoSummary `json:"TravelerInfoSummary"`
-	TPA_Extensions               TPAExtensions       `json:"TPA_Extensions"`
+// CancelBookingRequest cancels some or all segments of a reservation
+type CancelBookingRequest struct {
+	RecordLocator string   `uri:"pnr" binding:"required"`
+	Segments      []string `form:"segment"` // Segment IDs to cancel; empty cancels the whole reservation
 }
 
-type TravelPreferences struct {
-	Baggage Baggage `json:"Baggage"`
+// Validate ensures the record locator is well formed
+func (r *CancelBookingRequest) Validate() error {
+	return ValidateRecordLocator(r.RecordLocator)
 }
 
-type Baggage struct {
-	CarryOnInfo bool   `json:"CarryOnInfo"`
-	Description bool   `json:"Description"`
-	RequestType string `json:"RequestType"`
+// ValidateRecordLocator checks that a record locator is six letters or digits
+func ValidateRecordLocator(locator string) error {
+	if !recordLocatorPattern.MatchString(locator) {
+		return fmt.Errorf("invalid record locator %q, expected 6 uppercase letters or digits", locator)
+	}
+	return nil
 }
-type SabreRequestFormat struct {
-	OTA_AirLowFareSearchRQ OTA_AirLowFareSearchRQ `json:"OTA_AirLowFareSearchRQ"`
+
+// ValidateItineraryID ensures an itinerary ID is well formed
+func ValidateItineraryID(id string) error {
+	if !itineraryIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid offer id %q, expected %d lowercase hexadecimal characters", id, domain.ItineraryIDLength)
+	}
+	return nil
 }
-type POS struct {
-	Source []Source `json:"Source"`
+
+// PaymentCardDetails is a credit card used to pay for tickets
+type PaymentCardDetails struct {
+	Code       string `json:"code" binding:"required,len=2"` // Card vendor code, e.g. VI or CA
+	Number     string `json:"number" binding:"required,numeric,min=12,max=19"`
+	ExpiryDate string `json:"expiry_date" binding:"required"` // Format: YYYY-MM
 }
 
-type Source struct {
-	PseudoCityCode string      `json:"PseudoCityCode"`
-	RequestorID    RequestorID `json:"RequestorID"`
+// FormOfPaymentDetails is how the tickets are paid for
+type FormOfPaymentDetails struct {
+	Type string              `json:"type" binding:"required,oneof=cash card"`
+	Card *PaymentCardDetails `json:"card"`
 }
 
-type RequestorID struct {
-	Type        string      `json:"Type"`
-	ID          string      `json:"ID"`
-	CompanyName CompanyName `json:"CompanyName"`
+// TicketRequest issues the tickets of a held reservation
+// The validating carrier is the one of the price quote stored with the reservation.
+type TicketRequest struct {
+	RecordLocator string               `uri:"pnr"`
+	Payment       FormOfPaymentDetails `json:"payment" binding:"required"`
 }
 
-type CompanyName struct {
-	Code string `json:"Code"`
+// Validate ensures the record locator and form of payment are usable
+func (r *TicketRequest) Validate() error {
+	if err := ValidateRecordLocator(r.RecordLocator); err != nil {
+		return err
+	}
+	if r.Payment.Type != domain.PaymentTypeCard {
+		return nil
+	}
+	if r.Payment.Card == nil {
+		return fmt.Errorf("card details are required when paying by card")
+	}
+	expiry, err := time.Parse("2006-01", r.Payment.Card.ExpiryDate)
+	if err != nil {
+		return fmt.Errorf("invalid card expiry_date %q, expected YYYY-MM", r.Payment.Card.ExpiryDate)
+	}
+	// Cards are valid until the end of their expiry month
+	if !expiry.AddDate(0, 1, 0).After(time.Now()) {
+		return fmt.Errorf("card has expired")
+	}
+	return nil
 }
 
-// OriginDestinationInformation related structs
-type OriginDestinationInfo struct {
-	DepartureDateTime   string          `json:"DepartureDateTime"`
-	OriginLocation      RequestLocation `json:"OriginLocation"`
-	DestinationLocation RequestLocation `json:"DestinationLocation"`
+// ToTicketingRequest converts the API request into the provider-neutral ticketing request
+// Args:
+//
+//	validatingCarrier - Validating carrier of the reservation's stored price quote
+//
+// Returns:
+//
+//	Pointer to the provider-neutral ticketing request
+func (r *TicketRequest) ToTicketingRequest(validatingCarrier string) *domain.TicketingRequest {
+	req := &domain.TicketingRequest{
+		RecordLocator:     r.RecordLocator,
+		ValidatingCarrier: validatingCarrier,
+		Payment:           domain.FormOfPayment{Type: r.Payment.Type},
+	}
+	if r.Payment.Type == domain.PaymentTypeCard && r.Payment.Card != nil {
+		req.Payment.Card = &domain.PaymentCard{
+			Code:       r.Payment.Card.Code,
+			Number:     r.Payment.Card.Number,
+			ExpiryDate: r.Payment.Card.ExpiryDate,
+		}
+	}
+	return req
 }
 
-type VendorPref struct {
-	Code string `json:"Code"`
+// SeatMapRequest asks for the seat layout of a segment from the search results
+type SeatMapRequest struct {
+	MarketingCarrier string `json:"marketing_carrier" binding:"required,len=2"`
+	FlightNumber     int    `json:"flight_number" binding:"required,min=1"`
+	OperatingCarrier string `json:"operating_carrier"`
+	DepartureDate    string `json:"departure_date" binding:"required"`    // Format: YYYY-MM-DD
+	Origin           string `json:"origin" binding:"required,len=3"`      // IATA code
+	Destination      string `json:"destination" binding:"required,len=3"` // IATA code
+	BookingClass     string `json:"booking_class" binding:"required,min=1,max=2"`
 }
 
-// TravelerInfoSummary related structs
-type TravelerInfoSummary struct {
-	AirTravelerAvail []AirTravelerAvail `json:"AirTravelerAvail"`
+// Validate ensures the departure date is well formed
+func (r *SeatMapRequest) Validate() error {
+	if _, err := time.Parse("2006-01-02", r.DepartureDate); err != nil {
+		return fmt.Errorf("invalid departure_date %q, expected YYYY-MM-DD", r.DepartureDate)
+	}
+	return nil
 }
 
-type AirTravelerAvail struct {
-	PassengerTypeQuantity []PassengerTypeQuantity `json:"PassengerTypeQuantity"`
+// ToSeatMapQuery converts the API request into the provider-neutral seat map query
+func (r *SeatMapRequest) ToSeatMapQuery() *domain.SeatMapQuery {
+	return &domain.SeatMapQuery{
+		MarketingCarrier: r.MarketingCarrier,
+		FlightNumber:     r.FlightNumber,
+		OperatingCarrier: r.OperatingCarrier,
+		DepartureDate:    r.DepartureDate,
+		Origin:           r.Origin,
+		Destination:      r.Destination,
+		BookingClass:     r.BookingClass,
+	}
 }
 
-type PassengerTypeQuantity struct {
-	Code     string `json:"Code"`
-	Quantity int    `json:"Quantity"`
+// FareComponentDetails is a fare component taken from the search results
+type FareComponentDetails struct {
+	FareBasisCode    string `json:"fare_basis_code" binding:"required,max=15"`
+	GoverningCarrier string `json:"governing_carrier" binding:"required,len=2"`
+	BeginAirport     string `json:"begin_airport" binding:"required,len=3"`
+	EndAirport       string `json:"end_airport" binding:"required,len=3"`
+	DepartureDate    string `json:"departure_date" binding:"required"` // Format: YYYY-MM-DD
+	FareRule         string `json:"fare_rule"`
+	FareTariff       string `json:"fare_tariff"`
+	VendorCode       string `json:"vendor_code"`
 }
 
-// TPA_Extensions related structs
-type TPAExtensions struct {
-	IntelliSellTransaction IntelliSellTransaction `json:"IntelliSellTransaction"`
+// FareRulesRequest asks for the rules of an itinerary's fare components
+// The components are either given in full or taken from the itinerary ID of a search result.
+type FareRulesRequest struct {
+	OfferID    string                 `json:"offer_id"` // Itinerary ID from the search results
+	Components []FareComponentDetails `json:"components" binding:"omitempty,max=8,dive"`
 }
 
-type IntelliSellTransaction struct {
-	RequestType RequestType `json:"RequestType"`
+// Validate ensures the travel dates are well formed
+func (r *FareRulesRequest) Validate() error {
+	if r.OfferID != "" {
+		if len(r.Components) > 0 {
+			return fmt.Errorf("give either offer_id or components, not both")
+		}
+		return ValidateItineraryID(r.OfferID)
+	}
+	if len(r.Components) == 0 {
+		return fmt.Errorf("components are required without offer_id")
+	}
+	for i, fc := range r.Components {
+		if _, err := time.Parse("2006-01-02", fc.DepartureDate); err != nil {
+			return fmt.Errorf("component %d: invalid departure_date %q, expected YYYY-MM-DD", i+1, fc.DepartureDate)
+		}
+	}
+	return nil
 }
 
-type RequestType struct {
-	Name string `json:"Name"`
+// ToFareRulesQueries converts the API request into provider-neutral fare rule queries
+func (r *FareRulesRequest) ToFareRulesQueries() []domain.FareRulesQuery {
+	queries := make([]domain.FareRulesQuery, 0, len(r.Components))
+	for _, fc := range r.Components {
+		queries = append(queries, domain.FareRulesQuery{
+			FareBasisCode:    fc.FareBasisCode,
+			GoverningCarrier: fc.GoverningCarrier,
+			BeginAirport:     fc.BeginAirport,
+			EndAirport:       fc.EndAirport,
+			DepartureDate:    fc.DepartureDate,
+			FareRule:         fc.FareRule,
+			FareTariff:       fc.FareTariff,
+			VendorCode:       fc.VendorCode,
+		})
+	}
+	return queries
+}
+
+// LocationSearchRequest is the query of the location autocomplete endpoint
+type LocationSearchRequest struct {
+	Query string `form:"q" binding:"required,min=2,max=64"` // Code, airport name or city name
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
 }
+
+// DefaultLocationLimit is the number of locations returned when no limit is given
+const DefaultLocationLimit = 10
@@ -0,0 +1,62 @@
+package DTO
+
+import (
+	"testing"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+func TestValidatePassengers(t *testing.T) {
+	tests := []struct {
+		name       string
+		passengers []Passenger
+		wantErr    bool
+	}{
+		{name: "adults only", passengers: []Passenger{{Type: "ADT", Count: 2}}},
+		{name: "one infant per adult", passengers: []Passenger{{Type: "ADT", Count: 2}, {Type: "INF", Count: 2}}},
+		{name: "children do not need a lap", passengers: []Passenger{{Type: "ADT", Count: 1}, {Type: "CNN", Count: 3}}},
+		{name: "more infants than adults", passengers: []Passenger{{Type: "ADT", Count: 1}, {Type: "INF", Count: 2}}, wantErr: true},
+		{name: "infants counted across entries", passengers: []Passenger{{Type: "INF", Count: 1}, {Type: "ADT", Count: 1}, {Type: "INF", Count: 1}}, wantErr: true},
+		{name: "child without an adult", passengers: []Passenger{{Type: "CNN", Count: 1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePassengers(tt.passengers); (err != nil) != tt.wantErr {
+				t.Errorf("validatePassengers() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		tripType string
+		filters  SearchFilters
+		wantErr  bool
+	}{
+		{name: "one window on a one-way search", tripType: domain.TripOneWay, filters: SearchFilters{Legs: []LegTimeWindows{{DepartureAfter: "06:00"}}}},
+		{name: "two windows on a round trip", tripType: domain.TripRoundTrip, filters: SearchFilters{Legs: []LegTimeWindows{{}, {DepartureAfter: "18:00"}}}},
+		{name: "two windows on a one-way search", tripType: domain.TripOneWay, filters: SearchFilters{Legs: []LegTimeWindows{{}, {DepartureAfter: "18:00"}}}, wantErr: true},
+		{name: "window across midnight", tripType: domain.TripOneWay, filters: SearchFilters{Legs: []LegTimeWindows{{DepartureAfter: "22:00", DepartureBefore: "02:00"}}}},
+		{name: "malformed time", tripType: domain.TripOneWay, filters: SearchFilters{Legs: []LegTimeWindows{{ArrivalBefore: "25:00"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &FlightSearchRequest{
+				TripType:          tt.tripType,
+				Origin:            "JFK",
+				Destination:       "LAX",
+				DepartureDateTime: "2025-06-01",
+				ReturnDateTime:    "2025-06-08",
+				Passengers:        []Passenger{{Type: "ADT", Count: 1}},
+				Filters:           &tt.filters,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
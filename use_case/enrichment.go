@@ -0,0 +1,56 @@
+package use_case
+
+import (
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// enrichOffer names the carriers and aircraft of every segment from the reference data
+// Names a provider already supplied are kept, and unknown codes are left as they are.
+// When another airline operates a segment, a codeshare disclosure is added.
+func enrichOffer(offer *domain.Offer, airlines interfaces.AirlineDirectory, aircraft interfaces.AircraftDirectory) {
+	for i := range offer.Legs {
+		for j := range offer.Legs[i].Segments {
+			seg := &offer.Legs[i].Segments[j]
+			if airlines != nil {
+				if airline, ok := airlines.Airline(seg.MarketingCarrier); ok {
+					if seg.MarketingCarrierName == "" {
+						seg.MarketingCarrierName = airline.Name
+					}
+					if seg.CarrierLogoKey == "" {
+						seg.CarrierLogoKey = airline.LogoKey
+					}
+				}
+				if airline, ok := airlines.Airline(seg.OperatingCarrier); ok && seg.OperatingCarrierName == "" {
+					seg.OperatingCarrierName = airline.Name
+				}
+			}
+			if aircraft != nil {
+				if equipment, ok := aircraft.Aircraft(seg.Equipment); ok {
+					if seg.EquipmentName == "" {
+						seg.EquipmentName = equipment.Name
+					}
+					if seg.EquipmentCategory == "" {
+						seg.EquipmentCategory = equipment.Category
+					}
+				}
+			}
+			if seg.CodeshareDisclosure == "" {
+				seg.CodeshareDisclosure = codeshareDisclosure(seg)
+			}
+		}
+	}
+}
+
+// codeshareDisclosure returns the "operated by" text of a segment, or "" when the
+// marketing carrier flies it
+func codeshareDisclosure(seg *domain.Segment) string {
+	if seg.OperatingCarrier == "" || seg.OperatingCarrier == seg.MarketingCarrier {
+		return ""
+	}
+	operator := seg.OperatingCarrierName
+	if operator == "" {
+		operator = seg.OperatingCarrier
+	}
+	return "Operated by " + operator
+}
@@ -0,0 +1,42 @@
+package use_case
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// GetFareRules returns the rules of every fare component of an itinerary
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The fare components taken from the search results, or their itinerary ID
+//
+// Returns:
+//
+//	Pointer to FareRulesResponse with one entry per component or an error if any lookup fails
+func (s *FlightService) GetFareRules(ctx context.Context, req *DTO.FareRulesRequest) (*DTO.FareRulesResponse, error) {
+	if s.FareRules == nil {
+		return nil, domain.ErrNotSupported
+	}
+
+	queries := req.ToFareRulesQueries()
+	if req.OfferID != "" {
+		snapshot, err := loadSnapshot(ctx, s.Offers, req.OfferID)
+		if err != nil {
+			return nil, err
+		}
+		queries = snapshot.FareRulesQueries()
+	}
+	response := &DTO.FareRulesResponse{Rules: make([]domain.FareRules, 0, len(queries))}
+	for i := range queries {
+		rules, err := s.FareRules.FareRules(ctx, &queries[i])
+		if err != nil {
+			return nil, fmt.Errorf("fare rules for %s %s-%s: %w", queries[i].FareBasisCode, queries[i].BeginAirport, queries[i].EndAirport, err)
+		}
+		response.Rules = append(response.Rules, *rules)
+	}
+	return response, nil
+}
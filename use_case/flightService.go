@@ -0,0 +1,298 @@
+package use_case
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// FlightService implements the flight use cases on top of one or more content providers
+type FlightService struct {
+	Providers       []interfaces.FlightProvider   // Content sources searched in parallel
+	Revalidator     interfaces.Revalidator        // Re-prices itineraries selected without an ID; offers by ID go to the provider that found them
+	SeatMaps        interfaces.SeatMapProvider    // Returns seat layouts; nil when unsupported
+	FareRules       interfaces.FareRulesProvider  // Returns fare rules; nil when unsupported
+	Currency        *CurrencyService              // Converts prices to the requested currency; nil when unsupported
+	Markup          *MarkupService                // Sets selling prices; nil sells at net
+	Locations       interfaces.LocationDirectory  // Expands city codes; nil sends codes as given
+	Emissions       interfaces.EmissionsEstimator // Estimates CO2 per passenger; nil leaves offers without estimates
+	Airlines        interfaces.AirlineDirectory   // Names carriers; nil leaves the codes only
+	Aircraft        interfaces.AircraftDirectory  // Names aircraft types; nil leaves the codes only
+	Offers          interfaces.OfferStore         // Keeps returned offers for lookup by ID; nil keeps none
+	ProviderTimeout time.Duration                 // Maximum time a single provider may take
+}
+
+// NewFlightService creates and initializes a new FlightService instance
+// Args:
+//
+//	providers - The content sources to search against
+//	revalidator - The provider used to re-price selected itineraries, or nil
+//	seatMaps - The provider used to fetch seat maps, or nil
+//	fareRules - The provider used to fetch fare rules, or nil
+//	currency - The service converting prices to a requested currency, or nil
+//	markup - The service applying markup rules, or nil
+//	locations - The airport and city reference data used to validate searches, or nil
+//	emissions - The estimator attaching CO2 figures to offers, or nil
+//	airlines - The airline reference data used to enrich segments, or nil
+//	aircraft - The aircraft reference data used to enrich segments, or nil
+//	offers - The store keeping returned offers for later lookup, or nil
+//	providerTimeout - Maximum time a single provider may take to answer
+//
+// Returns:
+//
+//	Pointer to a new FlightService instance
+func NewFlightService(providers []interfaces.FlightProvider, revalidator interfaces.Revalidator, seatMaps interfaces.SeatMapProvider, fareRules interfaces.FareRulesProvider, currency *CurrencyService, markup *MarkupService, locations interfaces.LocationDirectory, emissions interfaces.EmissionsEstimator, airlines interfaces.AirlineDirectory, aircraft interfaces.AircraftDirectory, offers interfaces.OfferStore, providerTimeout time.Duration) *FlightService {
+	return &FlightService{
+		Providers:       providers,
+		Revalidator:     revalidator,
+		SeatMaps:        seatMaps,
+		FareRules:       fareRules,
+		Currency:        currency,
+		Markup:          markup,
+		Locations:       locations,
+		Emissions:       emissions,
+		Airlines:        airlines,
+		Aircraft:        aircraft,
+		Offers:          offers,
+		ProviderTimeout: providerTimeout,
+	}
+}
+
+// providerResult is the outcome of searching a single provider
+type providerResult struct {
+	name     string
+	result   *domain.SearchResult
+	err      error
+	timedOut bool
+}
+
+// SearchFlights queries every provider concurrently and merges their offers
+// A slow or failing provider is reported as a warning; the search only fails
+// when no provider answered.
+// Args:
+//
+//	ctx - Context controlling the lifetime of the search
+//	req - The flight search request from the client
+//
+// Returns:
+//
+//	Pointer to FlightSearchResponse with the offers found or an error if the search fails
+func (s *FlightService) SearchFlights(ctx context.Context, req *DTO.FlightSearchRequest) (*DTO.FlightSearchResponse, error) {
+	if len(s.Providers) == 0 {
+		return nil, domain.ErrNoProviders
+	}
+
+	// Reject unknown codes before spending a provider call on them
+	query := req.ToQuery()
+	if err := resolveLocations(s.Locations, query); err != nil {
+		return nil, err
+	}
+
+	results := make([]providerResult, len(s.Providers))
+	var wg sync.WaitGroup
+	for i, provider := range s.Providers {
+		wg.Add(1)
+		go func(i int, provider interfaces.FlightProvider) {
+			defer wg.Done()
+			results[i] = s.searchProvider(ctx, provider, query)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	response := &DTO.FlightSearchResponse{
+		OriginAirports:      query.OriginAirports,
+		DestinationAirports: query.DestinationAirports,
+	}
+	var offers []domain.Offer
+	var errs []string
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", res.name, res.err))
+			response.Warnings = append(response.Warnings, providerWarning(res))
+			continue
+		}
+		offers = append(offers, res.result.Offers...)
+		response.Warnings = append(response.Warnings, res.result.Warnings...)
+	}
+
+	// Only fail the search when there is nothing to show
+	if len(errs) == len(results) {
+		if len(errs) == 1 {
+			return nil, results[0].err
+		}
+		return nil, fmt.Errorf("all providers failed: %s", strings.Join(errs, "; "))
+	}
+
+	// Offers that cannot seat every passenger would fail at booking
+	offers, response.Warnings = dropUnseatable(offers, query.SeatedPassengers(), response.Warnings)
+
+	// Segments are enriched with reference data and emissions are estimated before
+	// filtering so they can be filtered and sorted on
+	for i := range offers {
+		enrichOffer(&offers[i], s.Airlines, s.Aircraft)
+		if s.Emissions != nil {
+			s.Emissions.Estimate(&offers[i])
+		}
+	}
+
+	// Providers may not honour every preference, so the offers are checked again
+	if query.Preferences != nil {
+		kept := offers[:0]
+		for _, offer := range offers {
+			if query.Preferences.Accepts(&offer) {
+				kept = append(kept, offer)
+			}
+		}
+		offers = kept
+	}
+
+	// Markup is applied in the provider's currency, before any conversion; lap
+	// infants have no seat, so they pay no per-passenger markup
+	if s.Markup != nil {
+		s.Markup.Apply(offers, req.Channel, query.SeatedPassengers())
+	}
+
+	// Bag fees are in the provider's currency too, so the baggage-inclusive total is priced here
+	if req.CheckedBags > 0 {
+		for i := range offers {
+			offers[i].PriceWithBags = offers[i].PriceWithCheckedBags(req.CheckedBags)
+		}
+	}
+
+	// Convert before merging so the same flights priced in different currencies can be compared
+	if req.Currency != "" {
+		if s.Currency == nil {
+			return nil, fmt.Errorf("%w: currency conversion", domain.ErrNotSupported)
+		}
+		converted, warnings, err := s.Currency.ConvertOffers(ctx, offers, req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		offers = converted
+		response.Warnings = append(response.Warnings, warnings...)
+	}
+
+	// IDs are derived once prices are final, so they cover the price shown
+	for i := range offers {
+		offers[i].ComputeItineraryID(query.Passengers, req.Channel)
+	}
+	response.Flights = mergeOffers(offers)
+	if req.SortBy == DTO.SortByEmissions {
+		sortByEmissions(response.Flights)
+	}
+
+	// Snapshots let revalidation, fare rules, booking and share links refer to an offer by ID
+	if s.Offers != nil {
+		for _, offer := range response.Flights {
+			snapshot := &domain.OfferSnapshot{Offer: offer, Passengers: query.Passengers, Channel: req.Channel}
+			if err := s.Offers.Save(ctx, snapshot); err != nil {
+				return nil, fmt.Errorf("failed to keep offer %s: %w", offer.ItineraryID, err)
+			}
+			response.OffersExpireAt = snapshot.ExpiresAt.Format(time.RFC3339)
+		}
+	}
+	return response, nil
+}
+
+// searchProvider runs a single provider search bounded by the provider timeout
+func (s *FlightService) searchProvider(ctx context.Context, provider interfaces.FlightProvider, query *domain.SearchQuery) (res providerResult) {
+	res.name = provider.Name()
+
+	// A panicking adapter must not take the other providers down with it
+	defer func() {
+		if r := recover(); r != nil {
+			res.result, res.err = nil, fmt.Errorf("provider panicked: %v", r)
+		}
+	}()
+
+	if s.ProviderTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.ProviderTimeout)
+		defer cancel()
+	}
+	res.result, res.err = provider.SearchFlights(ctx, query)
+	res.timedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+	if res.err == nil && res.result == nil {
+		res.result = &domain.SearchResult{}
+	}
+	return res
+}
+
+// providerWarning turns a provider failure into a response warning
+func providerWarning(res providerResult) domain.Warning {
+	code := domain.WarningProviderFailed
+	if res.timedOut {
+		code = domain.WarningProviderTimeout
+	}
+	return domain.Warning{
+		Code:    code,
+		Message: res.err.Error(),
+		Source:  res.name,
+	}
+}
+
+// dropUnseatable removes the offers with fewer seats left than passengers needing one
+// Offers that do not report their seats are kept. Lap infants take no seat and are
+// not counted: the request allows one per adult, and providers do not report the
+// infants a flight can still take, so that limit is left to the airline at booking.
+func dropUnseatable(offers []domain.Offer, seated int, warnings []domain.Warning) ([]domain.Offer, []domain.Warning) {
+	kept := offers[:0]
+	for _, offer := range offers {
+		if seats, ok := offer.SeatsAvailable(); ok && seats < seated {
+			warnings = append(warnings, domain.Warning{
+				Code:    domain.WarningInsufficientSeats,
+				Message: fmt.Sprintf("%d seats left for %d passengers", seats, seated),
+				Source:  offer.Source,
+				OfferID: offer.ID,
+			})
+			continue
+		}
+		kept = append(kept, offer)
+	}
+	return kept, warnings
+}
+
+// sortByEmissions orders offers by their CO2 per passenger, keeping the price order among equals
+// Offers without an estimate come last.
+func sortByEmissions(offers []domain.Offer) {
+	sort.SliceStable(offers, func(i, j int) bool {
+		a, b := offers[i].CO2Kg, offers[j].CO2Kg
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+}
+
+// mergeOffers de-duplicates offers flying the same flights and sorts them by price
+// When several providers sell the same itinerary in the same currency only the
+// cheapest offer is kept; offers in different currencies cannot be compared and
+// are all kept.
+func mergeOffers(offers []domain.Offer) []domain.Offer {
+	best := make(map[string]int, len(offers))
+	merged := make([]domain.Offer, 0, len(offers))
+	for _, offer := range offers {
+		key := offer.ItineraryKey() + "#" + offer.Price.Currency
+		if idx, ok := best[key]; ok {
+			if offer.Price.Total < merged[idx].Price.Total {
+				merged[idx] = offer
+			}
+			continue
+		}
+		best[key] = len(merged)
+		merged = append(merged, offer)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Price.Total < merged[j].Price.Total
+	})
+	return merged
+}
@@ -0,0 +1,390 @@
+package use_case
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+	"github.com/Yordi-SE/FlightSearch/providers/snapshots"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// stubProvider is a FlightProvider answering with a canned result
+type stubProvider struct {
+	name   string
+	result *domain.SearchResult
+	err    error
+	block  bool // Wait for the context to end instead of answering
+	panic  bool
+}
+
+func (p *stubProvider) Name() string {
+	return p.name
+}
+
+func (p *stubProvider) SearchFlights(ctx context.Context, query *domain.SearchQuery) (*domain.SearchResult, error) {
+	if p.panic {
+		panic("adapter bug")
+	}
+	if p.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return p.result, p.err
+}
+
+// testOffer returns a one-segment JFK-LAX offer
+func testOffer(source, carrier string, flight int, total float64, currency string) domain.Offer {
+	return domain.Offer{
+		ID:     carrier,
+		Source: source,
+		Legs: []domain.Leg{{
+			DepartureDate: "2025-06-01",
+			Origin:        "JFK",
+			Destination:   "LAX",
+			Segments: []domain.Segment{{
+				Departure:             domain.Endpoint{Airport: "JFK", Time: "08:00:00-04:00"},
+				Arrival:               domain.Endpoint{Airport: "LAX", Time: "11:20:00-07:00"},
+				MarketingCarrier:      carrier,
+				MarketingFlightNumber: flight,
+				OperatingCarrier:      carrier,
+				OperatingFlightNumber: flight,
+			}},
+		}},
+		Price: domain.Price{Total: total, Currency: currency},
+	}
+}
+
+// testSearchRequest returns a one-way search for one adult
+func testSearchRequest() *DTO.FlightSearchRequest {
+	return &DTO.FlightSearchRequest{
+		TripType:          domain.TripOneWay,
+		Origin:            "JFK",
+		Destination:       "LAX",
+		DepartureDateTime: "2025-06-01",
+		Passengers:        []DTO.Passenger{{Type: domain.PassengerAdult, Count: 1}},
+	}
+}
+
+func TestMergeOffers(t *testing.T) {
+	tests := []struct {
+		name   string
+		offers []domain.Offer
+		want   []string // Source and price of the merged offers, in order
+	}{
+		{
+			name: "same itinerary keeps the cheapest",
+			offers: []domain.Offer{
+				testOffer("sabre:A", "DL", 400, 300, "USD"),
+				testOffer("sabre:B", "DL", 400, 280, "USD"),
+				testOffer("amadeus", "DL", 400, 290, "USD"),
+			},
+			want: []string{"sabre:B 280"},
+		},
+		{
+			name: "same itinerary in other currencies is kept",
+			offers: []domain.Offer{
+				testOffer("sabre:A", "DL", 400, 300, "USD"),
+				testOffer("amadeus", "DL", 400, 270, "EUR"),
+			},
+			want: []string{"amadeus 270", "sabre:A 300"},
+		},
+		{
+			name: "different flights are sorted by price",
+			offers: []domain.Offer{
+				testOffer("sabre:A", "DL", 400, 300, "USD"),
+				testOffer("sabre:A", "AA", 1201, 250, "USD"),
+				testOffer("amadeus", "UA", 15, 275, "USD"),
+			},
+			want: []string{"sabre:A 250", "amadeus 275", "sabre:A 300"},
+		},
+		{
+			name: "first offer wins a tie",
+			offers: []domain.Offer{
+				testOffer("sabre:A", "DL", 400, 300, "USD"),
+				testOffer("amadeus", "DL", 400, 300, "USD"),
+			},
+			want: []string{"sabre:A 300"},
+		},
+		{
+			name: "no offers",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := mergeOffers(tt.offers)
+			got := make([]string, 0, len(merged))
+			for _, offer := range merged {
+				got = append(got, fmt.Sprintf("%s %g", offer.Source, offer.Price.Total))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("mergeOffers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchFlightsProviders(t *testing.T) {
+	tests := []struct {
+		name         string
+		providers    []*stubProvider
+		wantErr      string
+		wantOffers   int
+		wantWarnings []string // Code and source of each warning, in order
+	}{
+		{
+			name: "offers from every provider are merged",
+			providers: []*stubProvider{
+				{name: "sabre:A", result: &domain.SearchResult{Offers: []domain.Offer{testOffer("sabre:A", "DL", 400, 300, "USD")}}},
+				{name: "amadeus", result: &domain.SearchResult{Offers: []domain.Offer{
+					testOffer("amadeus", "DL", 400, 280, "USD"),
+					testOffer("amadeus", "AA", 1201, 320, "USD"),
+				}}},
+			},
+			wantOffers: 2,
+		},
+		{
+			name: "provider warnings are passed on",
+			providers: []*stubProvider{
+				{name: "sabre:A", result: &domain.SearchResult{
+					Offers:   []domain.Offer{testOffer("sabre:A", "DL", 400, 300, "USD")},
+					Warnings: []domain.Warning{{Code: domain.WarningItinerarySkipped, Source: "sabre:A"}},
+				}},
+			},
+			wantOffers:   1,
+			wantWarnings: []string{domain.WarningItinerarySkipped + " sabre:A"},
+		},
+		{
+			name: "failing provider becomes a warning",
+			providers: []*stubProvider{
+				{name: "sabre:A", result: &domain.SearchResult{Offers: []domain.Offer{testOffer("sabre:A", "DL", 400, 300, "USD")}}},
+				{name: "amadeus", err: errors.New("status 500")},
+			},
+			wantOffers:   1,
+			wantWarnings: []string{domain.WarningProviderFailed + " amadeus"},
+		},
+		{
+			name: "slow provider times out",
+			providers: []*stubProvider{
+				{name: "sabre:A", result: &domain.SearchResult{Offers: []domain.Offer{testOffer("sabre:A", "DL", 400, 300, "USD")}}},
+				{name: "amadeus", block: true},
+			},
+			wantOffers:   1,
+			wantWarnings: []string{domain.WarningProviderTimeout + " amadeus"},
+		},
+		{
+			name: "panicking provider is recovered",
+			providers: []*stubProvider{
+				{name: "sabre:A", panic: true},
+				{name: "amadeus", result: &domain.SearchResult{Offers: []domain.Offer{testOffer("amadeus", "DL", 400, 280, "USD")}}},
+			},
+			wantOffers:   1,
+			wantWarnings: []string{domain.WarningProviderFailed + " sabre:A"},
+		},
+		{
+			name: "provider without result counts as empty",
+			providers: []*stubProvider{
+				{name: "sabre:A"},
+			},
+		},
+		{
+			name: "single provider error is returned as is",
+			providers: []*stubProvider{
+				{name: "sabre:A", err: errors.New("no flights available")},
+			},
+			wantErr: "no flights available",
+		},
+		{
+			name: "all providers failing fails the search",
+			providers: []*stubProvider{
+				{name: "sabre:A", err: errors.New("status 500")},
+				{name: "amadeus", panic: true},
+			},
+			wantErr: "all providers failed: sabre:A: status 500; amadeus: provider panicked: adapter bug",
+		},
+		{
+			name:    "no providers configured",
+			wantErr: domain.ErrNoProviders.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FlightService{ProviderTimeout: 20 * time.Millisecond}
+			for _, p := range tt.providers {
+				service.Providers = append(service.Providers, p)
+			}
+
+			resp, err := service.SearchFlights(context.Background(), testSearchRequest())
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(resp.Flights) != tt.wantOffers {
+				t.Errorf("got %d offers, want %d", len(resp.Flights), tt.wantOffers)
+			}
+			var got []string
+			for _, w := range resp.Warnings {
+				got = append(got, w.Code+" "+w.Source)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantWarnings, ",") {
+				t.Errorf("warnings = %v, want %v", got, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+// seatsOffer returns a JFK-LAX offer whose segment reports the given seats, or none when negative
+func seatsOffer(id string, seats int) domain.Offer {
+	offer := testOffer("sabre:A", "DL", 400, 300, "USD")
+	offer.ID = id
+	if seats >= 0 {
+		offer.Legs[0].Segments[0].SeatsAvailable = &seats
+	}
+	return offer
+}
+
+func TestDropUnseatable(t *testing.T) {
+	tests := []struct {
+		name         string
+		offers       []domain.Offer
+		seated       int
+		wantKept     string
+		wantWarnings string
+	}{
+		{name: "enough seats", offers: []domain.Offer{seatsOffer("A", 4), seatsOffer("B", 2)}, seated: 2, wantKept: "A,B"},
+		{name: "too few seats", offers: []domain.Offer{seatsOffer("A", 1), seatsOffer("B", 3)}, seated: 2, wantKept: "B", wantWarnings: "A"},
+		{name: "seats not reported", offers: []domain.Offer{seatsOffer("A", -1)}, seated: 9, wantKept: "A"},
+		{
+			name: "fewest seats of any segment",
+			offers: func() []domain.Offer {
+				offer := seatsOffer("A", 9)
+				segment := offer.Legs[0].Segments[0]
+				seats := 1
+				segment.SeatsAvailable = &seats
+				offer.Legs[0].Segments = append(offer.Legs[0].Segments, segment)
+				return []domain.Offer{offer}
+			}(),
+			seated:       2,
+			wantWarnings: "A",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, warnings := dropUnseatable(tt.offers, tt.seated, nil)
+			var gotKept, gotWarnings []string
+			for _, offer := range kept {
+				gotKept = append(gotKept, offer.ID)
+			}
+			for _, w := range warnings {
+				if w.Code != domain.WarningInsufficientSeats || w.Source != "sabre:A" {
+					t.Errorf("unexpected warning: %+v", w)
+				}
+				gotWarnings = append(gotWarnings, w.OfferID)
+			}
+			if strings.Join(gotKept, ",") != tt.wantKept || strings.Join(gotWarnings, ",") != tt.wantWarnings {
+				t.Errorf("kept %v with warnings for %v, want %q and %q", gotKept, gotWarnings, tt.wantKept, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestSearchFlightsLapInfantsNeedNoSeat(t *testing.T) {
+	service := &FlightService{Providers: []interfaces.FlightProvider{
+		&stubProvider{name: "sabre:A", result: &domain.SearchResult{Offers: []domain.Offer{seatsOffer("A", 2), seatsOffer("B", 1)}}},
+	}}
+	req := testSearchRequest()
+	req.Passengers = []DTO.Passenger{{Type: domain.PassengerAdult, Count: 2}, {Type: domain.PassengerInfant, Count: 2}}
+
+	resp, err := service.SearchFlights(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Flights) != 1 {
+		t.Fatalf("expected the offer with 2 seats to be kept for 2 adults with infants, got %d offers", len(resp.Flights))
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != domain.WarningInsufficientSeats {
+		t.Errorf("unexpected warnings: %+v", resp.Warnings)
+	}
+}
+
+func TestSearchFlightsSnapshotsPerPassengerMix(t *testing.T) {
+	store := snapshots.NewMemoryStore(time.Minute)
+	service := &FlightService{
+		Providers: []interfaces.FlightProvider{
+			&stubProvider{name: "sabre:A", result: &domain.SearchResult{Offers: []domain.Offer{testOffer("sabre:A", "DL", 400, 300, "USD")}}},
+		},
+		Offers: store,
+	}
+	search := func(adults int, channel string) string {
+		t.Helper()
+		req := testSearchRequest()
+		req.Passengers = []DTO.Passenger{{Type: domain.PassengerAdult, Count: adults}}
+		req.Channel = channel
+		resp, err := service.SearchFlights(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Flights) != 1 {
+			t.Fatalf("expected 1 offer, got %d", len(resp.Flights))
+		}
+		return resp.Flights[0].ItineraryID
+	}
+
+	single := search(1, "")
+	group := search(3, "")
+	mobile := search(1, "mobile")
+	if single == group || single == mobile {
+		t.Fatalf("searches for other passengers or channels share offer ID %s", single)
+	}
+	if again := search(1, ""); again != single {
+		t.Errorf("the same search got offer ID %s, then %s", single, again)
+	}
+
+	for id, want := range map[string]int{single: 1, group: 3} {
+		snapshot, err := store.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("snapshot %s: %v", id, err)
+		}
+		if len(snapshot.Passengers) != 1 || snapshot.Passengers[0].Count != want {
+			t.Errorf("snapshot %s priced for %+v, want %d adults", id, snapshot.Passengers, want)
+		}
+	}
+}
+
+func TestSearchFlightsMarkupSkipsLapInfants(t *testing.T) {
+	service := &FlightService{
+		Providers: []interfaces.FlightProvider{
+			&stubProvider{name: "sabre:A", result: &domain.SearchResult{Offers: []domain.Offer{testOffer("sabre:A", "DL", 400, 300, "USD")}}},
+		},
+		Markup: NewMarkupService(&domain.MarkupRuleSet{Version: 1, Rules: []domain.MarkupRule{
+			{ID: "fee", Type: domain.MarkupTypeMarkup, Fixed: 5, PerPassenger: true, Currency: "USD"},
+		}}),
+	}
+	req := testSearchRequest()
+	req.Passengers = []DTO.Passenger{{Type: domain.PassengerAdult, Count: 2}, {Type: domain.PassengerInfant, Count: 1}}
+
+	resp, err := service.SearchFlights(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Flights) != 1 {
+		t.Fatalf("expected 1 offer, got %d", len(resp.Flights))
+	}
+	if got := resp.Flights[0].Price.Markup; got != 10 {
+		t.Errorf("markup = %v, want 10 for two adults and a lap infant", got)
+	}
+}
@@ -0,0 +1,113 @@
+package use_case
+
+import (
+	"context"
+	"fmt"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// LocationService implements the airport and city reference data use cases
+type LocationService struct {
+	Directory interfaces.LocationDirectory // Airport and city reference data
+}
+
+// NewLocationService creates and initializes a new LocationService instance
+// Args:
+//
+//	directory - The airport and city reference data
+//
+// Returns:
+//
+//	Pointer to a new LocationService instance
+func NewLocationService(directory interfaces.LocationDirectory) *LocationService {
+	return &LocationService{
+		Directory: directory,
+	}
+}
+
+// SearchLocations returns the airports and cities matching the query, best matches first
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The autocomplete query from the client
+//
+// Returns:
+//
+//	Pointer to LocationSearchResponse with the matching locations
+func (s *LocationService) SearchLocations(ctx context.Context, req *DTO.LocationSearchRequest) (*DTO.LocationSearchResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = DTO.DefaultLocationLimit
+	}
+	locations := s.Directory.Search(req.Query, limit)
+	if locations == nil {
+		locations = []domain.Location{}
+	}
+	return &DTO.LocationSearchResponse{Locations: locations}, nil
+}
+
+// resolveLocations expands the origin and destination of a query against the reference data
+// A city code is searched as a city covering all of its airports; a city inside a
+// list of airports is replaced by its airports. A code naming both an airport and
+// a city is searched as the airport unless the query asks for the city. Codes
+// missing from the reference data are rejected with domain.ErrUnknownLocation;
+// without reference data all codes are sent as airports.
+func resolveLocations(directory interfaces.LocationDirectory, query *domain.SearchQuery) error {
+	if directory == nil {
+		return nil
+	}
+	var err error
+	query.Origin, query.OriginType, query.OriginAirports, err = resolveLocation(directory, "origin", query.Origin, query.OriginType, query.OriginAirports)
+	if err != nil {
+		return err
+	}
+	query.Destination, query.DestinationType, query.DestinationAirports, err = resolveLocation(directory, "destination", query.Destination, query.DestinationType, query.DestinationAirports)
+	return err
+}
+
+// resolveLocation resolves a single code or a list of codes into the code, type and airports to search
+func resolveLocation(directory interfaces.LocationDirectory, field, code, locationType string, list []string) (string, string, []string, error) {
+	if len(list) == 0 {
+		if locationType == domain.LocationCity {
+			city, ok := directory.City(code)
+			if !ok {
+				return "", "", nil, fmt.Errorf("%w: %s %q is not a city", domain.ErrUnknownLocation, field, code)
+			}
+			return city.Code, domain.LocationCity, city.Airports, nil
+		}
+		loc, ok := directory.Lookup(code)
+		if !ok {
+			return "", "", nil, fmt.Errorf("%w: %s %q", domain.ErrUnknownLocation, field, code)
+		}
+		if loc.Type == domain.LocationCity {
+			return loc.Code, domain.LocationCity, loc.Airports, nil
+		}
+		return loc.Code, domain.LocationAirport, nil, nil
+	}
+
+	var airports []string
+	seen := make(map[string]bool)
+	for _, c := range list {
+		loc, ok := directory.Lookup(c)
+		if !ok {
+			return "", "", nil, fmt.Errorf("%w: %s %q", domain.ErrUnknownLocation, field, c)
+		}
+		members := []string{loc.Code}
+		if loc.Type == domain.LocationCity {
+			members = loc.Airports
+		}
+		for _, airport := range members {
+			if !seen[airport] {
+				seen[airport] = true
+				airports = append(airports, airport)
+			}
+		}
+	}
+	if len(airports) == 1 {
+		return airports[0], domain.LocationAirport, nil, nil
+	}
+	return airports[0], domain.LocationAirport, airports, nil
+}
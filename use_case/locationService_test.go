@@ -0,0 +1,71 @@
+package use_case
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+	"github.com/Yordi-SE/FlightSearch/providers/locations"
+)
+
+func TestResolveLocation(t *testing.T) {
+	directory, err := locations.NewDirectory()
+	if err != nil {
+		t.Fatalf("loading locations: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		code         string
+		locationType string
+		list         []string
+		wantCode     string
+		wantType     string
+		wantAirports string
+		wantErr      error
+	}{
+		{name: "known airport", code: "JFK", locationType: domain.LocationAirport, wantCode: "JFK", wantType: domain.LocationAirport},
+		{name: "unknown code", code: "XQZ", locationType: domain.LocationAirport, wantErr: domain.ErrUnknownLocation},
+		{name: "city expands to its airports", code: "LON", locationType: domain.LocationAirport, wantCode: "LON", wantType: domain.LocationCity, wantAirports: "LHR,LGW,STN,LTN,LCY,SEN"},
+		{name: "shared code defaults to the airport", code: "SHA", locationType: domain.LocationAirport, wantCode: "SHA", wantType: domain.LocationAirport},
+		{name: "shared code searched as the city", code: "SHA", locationType: domain.LocationCity, wantCode: "SHA", wantType: domain.LocationCity, wantAirports: "PVG,SHA"},
+		{name: "city type on a city-only code", code: "LON", locationType: domain.LocationCity, wantCode: "LON", wantType: domain.LocationCity, wantAirports: "LHR,LGW,STN,LTN,LCY,SEN"},
+		{name: "city type on an airport-only code", code: "JFK", locationType: domain.LocationCity, wantErr: domain.ErrUnknownLocation},
+		{name: "city type on an unknown code", code: "XQZ", locationType: domain.LocationCity, wantErr: domain.ErrUnknownLocation},
+		{
+			name: "list keeps airports", code: "LHR", locationType: domain.LocationAirport, list: []string{"LHR", "JFK"},
+			wantCode: "LHR", wantType: domain.LocationAirport, wantAirports: "LHR,JFK",
+		},
+		{name: "list with an unknown code", code: "LHR", locationType: domain.LocationAirport, list: []string{"LHR", "XQZ"}, wantErr: domain.ErrUnknownLocation},
+		{
+			name: "list expands cities without duplicates", code: "IST", locationType: domain.LocationAirport, list: []string{"SAW", "IST"},
+			wantCode: "SAW", wantType: domain.LocationAirport, wantAirports: "SAW,IST",
+		},
+		{
+			name: "list collapsing to one airport", code: "PVG", locationType: domain.LocationAirport, list: []string{"PVG", "PVG"},
+			wantCode: "PVG", wantType: domain.LocationAirport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, locationType, airports, err := resolveLocation(directory, "origin", tt.code, tt.locationType, tt.list)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if code != tt.wantCode || locationType != tt.wantType {
+				t.Errorf("resolved %s %s, want %s %s", locationType, code, tt.wantType, tt.wantCode)
+			}
+			if got := strings.Join(airports, ","); got != tt.wantAirports {
+				t.Errorf("airports = %q, want %q", got, tt.wantAirports)
+			}
+		})
+	}
+}
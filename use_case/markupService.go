@@ -0,0 +1,135 @@
+package use_case
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+// DefaultChannel is used for searches that do not name a sales channel
+const DefaultChannel = "default"
+
+// MarkupService applies the configured markup and discount rules to offers
+type MarkupService struct {
+	Rules []domain.MarkupRule // Sorted by priority
+}
+
+// NewMarkupService creates and initializes a new MarkupService instance
+// Args:
+//
+//	rules - The markup rule set loaded from configuration
+//
+// Returns:
+//
+//	Pointer to a new MarkupService instance
+func NewMarkupService(rules *domain.MarkupRuleSet) *MarkupService {
+	sorted := append([]domain.MarkupRule(nil), rules.Rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+	return &MarkupService{
+		Rules: sorted,
+	}
+}
+
+// Apply sets the selling price of every offer from the first matching rule
+// The provider price is kept as the net fare; offers no rule matches are sold at net.
+// Args:
+//
+//	offers - The offers to price, modified in place
+//	channel - Sales channel of the search
+//	passengers - Number of seated passengers the offers are priced for
+func (s *MarkupService) Apply(offers []domain.Offer, channel string, passengers int) {
+	if channel == "" {
+		channel = DefaultChannel
+	}
+	for i := range offers {
+		offer := &offers[i]
+		rule := s.match(offer, channel)
+		offer.Price = applyRule(rule, offer.Price, passengers)
+		if len(offer.FareFamilies) == 0 {
+			continue
+		}
+
+		// Brands are marked up individually, so the differences move with them
+		families := make([]domain.FareFamily, len(offer.FareFamilies))
+		for j, family := range offer.FareFamilies {
+			family.Price = applyRule(rule, family.Price, passengers)
+			families[j] = family
+		}
+		for j := range families {
+			families[j].PriceDifference = domain.RoundAmount(families[j].Price.Total-families[0].Price.Total, families[j].Price.Currency)
+		}
+		offer.FareFamilies = families
+	}
+}
+
+// match returns the first rule matching the offer, or nil
+func (s *MarkupService) match(offer *domain.Offer, channel string) *domain.MarkupRule {
+	carrier, cabin := offer.ValidatingCarrier, ""
+	var origin, destination string
+	if len(offer.Legs) > 0 {
+		origin, destination = offer.Legs[0].Origin, offer.Legs[0].Destination
+		if segs := offer.Legs[0].Segments; len(segs) > 0 {
+			if carrier == "" {
+				carrier = segs[0].MarketingCarrier
+			}
+			cabin = segs[0].CabinCode()
+		}
+	}
+
+	for i := range s.Rules {
+		rule := &s.Rules[i]
+		if rule.Currency != "" && rule.Currency != offer.Price.Currency {
+			continue
+		}
+		if matches(rule.Channels, channel) && matches(rule.Carriers, carrier) && matches(rule.Origins, origin) &&
+			matches(rule.Destinations, destination) && matches(rule.Cabins, cabin) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// matches reports whether value is in list; an empty list matches everything
+func matches(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRule computes the selling price of a net price under a rule
+func applyRule(rule *domain.MarkupRule, price domain.Price, passengers int) domain.Price {
+	price.Net = price.Total
+	price.Markup = 0
+	if rule == nil {
+		return price
+	}
+
+	fixed := rule.Fixed
+	if rule.PerPassenger {
+		fixed *= float64(passengers)
+	}
+	amount := price.Net*rule.Percentage/100 + fixed
+	if rule.Min != nil {
+		amount = math.Max(amount, *rule.Min)
+	}
+	if rule.Max != nil {
+		amount = math.Min(amount, *rule.Max)
+	}
+	if rule.Type == domain.MarkupTypeDiscount {
+		// A discount never takes the selling price below zero
+		amount = -math.Min(amount, price.Net)
+	}
+
+	price.Markup = domain.RoundAmount(amount, price.Currency)
+	price.Total = domain.RoundAmount(price.Net+price.Markup, price.Currency)
+	return price
+}
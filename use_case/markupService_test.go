@@ -0,0 +1,133 @@
+package use_case
+
+import (
+	"testing"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+)
+
+func TestMarkupServiceApply(t *testing.T) {
+	amount := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name       string
+		rules      []domain.MarkupRule
+		channel    string
+		passengers int
+		offer      func(o *domain.Offer)
+		wantMarkup float64
+	}{
+		{
+			name:    "no rule sells at net",
+			rules:   []domain.MarkupRule{{ID: "web", Channels: []string{"web"}, Type: domain.MarkupTypeMarkup, Percentage: 5}},
+			channel: "mobile",
+		},
+		{
+			name: "lowest priority wins",
+			rules: []domain.MarkupRule{
+				{ID: "default", Priority: 100, Type: domain.MarkupTypeMarkup, Percentage: 2},
+				{ID: "web", Priority: 10, Channels: []string{"web"}, Type: domain.MarkupTypeMarkup, Percentage: 5},
+			},
+			channel:    "web",
+			wantMarkup: 10,
+		},
+		{
+			name: "equal priorities keep file order",
+			rules: []domain.MarkupRule{
+				{ID: "first", Priority: 10, Type: domain.MarkupTypeMarkup, Percentage: 1},
+				{ID: "second", Priority: 10, Type: domain.MarkupTypeMarkup, Percentage: 5},
+			},
+			wantMarkup: 2,
+		},
+		{
+			name: "rule in another currency is skipped",
+			rules: []domain.MarkupRule{
+				{ID: "eur", Priority: 10, Type: domain.MarkupTypeMarkup, Fixed: 20, Currency: "EUR"},
+				{ID: "usd", Priority: 20, Type: domain.MarkupTypeMarkup, Fixed: 7, Currency: "USD"},
+			},
+			wantMarkup: 7,
+		},
+		{
+			name:       "percentage and fixed add up",
+			rules:      []domain.MarkupRule{{ID: "a", Type: domain.MarkupTypeMarkup, Percentage: 3, Fixed: 10, Currency: "USD"}},
+			wantMarkup: 16,
+		},
+		{
+			name:       "fixed per passenger",
+			rules:      []domain.MarkupRule{{ID: "a", Type: domain.MarkupTypeMarkup, Percentage: 1, Fixed: 5, PerPassenger: true, Currency: "USD"}},
+			passengers: 3,
+			wantMarkup: 17,
+		},
+		{
+			name:       "min raises a small markup",
+			rules:      []domain.MarkupRule{{ID: "a", Type: domain.MarkupTypeMarkup, Percentage: 3, Min: amount(25), Max: amount(150), Currency: "USD"}},
+			wantMarkup: 25,
+		},
+		{
+			name:       "max caps a large markup",
+			rules:      []domain.MarkupRule{{ID: "a", Type: domain.MarkupTypeMarkup, Percentage: 50, Min: amount(25), Max: amount(40), Currency: "USD"}},
+			wantMarkup: 40,
+		},
+		{
+			name:       "capped discount",
+			rules:      []domain.MarkupRule{{ID: "a", Type: domain.MarkupTypeDiscount, Percentage: 10, Max: amount(15), Currency: "USD"}},
+			wantMarkup: -15,
+		},
+		{
+			name:       "discount never goes below zero",
+			rules:      []domain.MarkupRule{{ID: "a", Type: domain.MarkupTypeDiscount, Fixed: 500, Currency: "USD"}},
+			wantMarkup: -200,
+		},
+		{
+			name:  "cabin match uses normalized codes",
+			rules: []domain.MarkupRule{{ID: "premium", Cabins: []string{domain.CabinPremiumEconomy}, Type: domain.MarkupTypeMarkup, Percentage: 5}},
+			offer: func(o *domain.Offer) {
+				o.Legs[0].Segments[0].Cabin = "W"
+			},
+			wantMarkup: 10,
+		},
+		{
+			name:  "carrier and route match",
+			rules: []domain.MarkupRule{{ID: "et", Carriers: []string{"ET"}, Origins: []string{"ADD"}, Destinations: []string{"NBO"}, Type: domain.MarkupTypeMarkup, Percentage: 5}},
+			offer: func(o *domain.Offer) {
+				o.ValidatingCarrier = "ET"
+				o.Legs[0].Origin, o.Legs[0].Destination = "ADD", "NBO"
+			},
+			wantMarkup: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offer := domain.Offer{
+				Legs: []domain.Leg{{
+					Origin:      "JFK",
+					Destination: "LAX",
+					Segments:    []domain.Segment{{MarketingCarrier: "DL", Cabin: domain.CabinEconomy}},
+				}},
+				Price: domain.Price{Total: 200, Base: 170, Taxes: 30, Currency: "USD"},
+			}
+			if tt.offer != nil {
+				tt.offer(&offer)
+			}
+			if tt.passengers == 0 {
+				tt.passengers = 1
+			}
+
+			offers := []domain.Offer{offer}
+			service := NewMarkupService(&domain.MarkupRuleSet{Version: 1, Rules: tt.rules})
+			service.Apply(offers, tt.channel, tt.passengers)
+
+			price := offers[0].Price
+			if price.Net != 200 {
+				t.Errorf("net = %v, want 200", price.Net)
+			}
+			if price.Markup != tt.wantMarkup {
+				t.Errorf("markup = %v, want %v", price.Markup, tt.wantMarkup)
+			}
+			if price.Total != price.Net+price.Markup {
+				t.Errorf("total %v is not net %v plus markup %v", price.Total, price.Net, price.Markup)
+			}
+		})
+	}
+}
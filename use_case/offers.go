@@ -0,0 +1,40 @@
+package use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// GetOffer returns an offer from a recent search by its itinerary ID
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	itineraryID - The itinerary ID shown in the search results
+//
+// Returns:
+//
+//	Pointer to OfferResponse with the offer as it was shown or an error if it is unknown or expired
+func (s *FlightService) GetOffer(ctx context.Context, itineraryID string) (*DTO.OfferResponse, error) {
+	snapshot, err := loadSnapshot(ctx, s.Offers, itineraryID)
+	if err != nil {
+		return nil, err
+	}
+	return &DTO.OfferResponse{
+		Offer:      snapshot.Offer,
+		Passengers: snapshot.Passengers,
+		ExpiresAt:  snapshot.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// loadSnapshot looks up the snapshot of an offer, failing when no store is configured
+func loadSnapshot(ctx context.Context, store interfaces.OfferStore, itineraryID string) (*domain.OfferSnapshot, error) {
+	if store == nil {
+		return nil, fmt.Errorf("%w: offer snapshots", domain.ErrNotSupported)
+	}
+	return store.Get(ctx, itineraryID)
+}
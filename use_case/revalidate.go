@@ -0,0 +1,122 @@
+package use_case
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// priceTolerance absorbs rounding differences when comparing totals
+const priceTolerance = 0.005
+
+// RevalidateItinerary checks the current price and availability of a selected itinerary
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The selected itinerary, or its itinerary ID, and the total the client expects to pay
+//
+// Returns:
+//
+//	Pointer to RevalidateResponse describing the outcome or an error if the check fails
+func (s *FlightService) RevalidateItinerary(ctx context.Context, req *DTO.RevalidateRequest) (*DTO.RevalidateResponse, error) {
+	revalidator := s.Revalidator
+	sel, channel := req.ToSelection(), req.Channel
+	expectedTotal, expectedCurrency := req.ExpectedTotal, req.Currency
+	if req.OfferID != "" {
+		snapshot, err := loadSnapshot(ctx, s.Offers, req.OfferID)
+		if err != nil {
+			return nil, err
+		}
+		if revalidator, err = s.revalidatorFor(snapshot.Offer.Source); err != nil {
+			return nil, err
+		}
+		sel, channel = snapshot.Selection(), snapshot.Channel
+		// Revalidation prices in the provider's currency, so the total before conversion is expected
+		if expectedTotal == 0 {
+			expectedTotal, expectedCurrency = snapshot.Offer.Price.Total, snapshot.Offer.Price.Currency
+			if conversion := snapshot.Offer.Price.Conversion; conversion != nil {
+				expectedTotal, expectedCurrency = conversion.OriginalTotal, conversion.OriginalCurrency
+			}
+		}
+	}
+	if revalidator == nil {
+		return nil, domain.ErrNotSupported
+	}
+	result, err := revalidator.Revalidate(ctx, sel)
+	if errors.Is(err, domain.ErrNoAvailability) {
+		return &DTO.RevalidateResponse{
+			Status:        DTO.RevalidationUnavailable,
+			Message:       err.Error(),
+			PreviousTotal: expectedTotal,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Compare selling prices, as shown in the search results
+	if s.Markup != nil {
+		s.Markup.Apply(result.Offers, channel, domain.SeatedPassengers(sel.Passengers))
+	}
+
+	// Offers are sorted by price, so the first match is the cheapest
+	var offer *domain.Offer
+	for i := range result.Offers {
+		if sel.Matches(&result.Offers[i]) {
+			offer = &result.Offers[i]
+			break
+		}
+	}
+	if offer == nil {
+		return &DTO.RevalidateResponse{
+			Status:        DTO.RevalidationUnavailable,
+			Message:       domain.ErrNoAvailability.Error(),
+			PreviousTotal: expectedTotal,
+			Warnings:      result.Warnings,
+		}, nil
+	}
+
+	response := &DTO.RevalidateResponse{
+		Status:   DTO.RevalidationConfirmed,
+		Message:  "itinerary is available at the current price",
+		Offer:    offer,
+		Warnings: result.Warnings,
+	}
+	if expectedTotal == 0 {
+		return response, nil
+	}
+
+	response.PreviousTotal = expectedTotal
+	if expectedCurrency != "" && expectedCurrency != offer.Price.Currency {
+		response.Status = DTO.RevalidationPriceChanged
+		response.Message = fmt.Sprintf("itinerary is now priced in %s instead of %s", offer.Price.Currency, expectedCurrency)
+		return response, nil
+	}
+	difference := offer.Price.Total - expectedTotal
+	if math.Abs(difference) > priceTolerance {
+		response.Status = DTO.RevalidationPriceChanged
+		response.PriceDifference = math.Round(difference*100) / 100
+		response.Message = fmt.Sprintf("price changed from %.2f to %.2f %s", expectedTotal, offer.Price.Total, offer.Price.Currency)
+	}
+	return response, nil
+}
+
+// revalidatorFor returns the provider that re-prices offers found by the given source
+// An offer is only valid with the provider, and for Sabre the PCC, that priced it.
+func (s *FlightService) revalidatorFor(source string) (interfaces.Revalidator, error) {
+	for _, provider := range s.Providers {
+		if provider.Name() != source {
+			continue
+		}
+		if revalidator, ok := provider.(interfaces.Revalidator); ok {
+			return revalidator, nil
+		}
+		break
+	}
+	return nil, fmt.Errorf("%w: revalidating offers from %s", domain.ErrNotSupported, source)
+}
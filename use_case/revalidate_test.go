@@ -0,0 +1,84 @@
+package use_case
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	interfaces "github.com/Yordi-SE/FlightSearch/Interfaces"
+	"github.com/Yordi-SE/FlightSearch/domain"
+	"github.com/Yordi-SE/FlightSearch/providers/snapshots"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// stubRevalidator is a provider that re-prices every selection with the offers it was given
+type stubRevalidator struct {
+	stubProvider
+	revalidated int
+}
+
+func (p *stubRevalidator) Revalidate(ctx context.Context, sel *domain.ItinerarySelection) (*domain.SearchResult, error) {
+	p.revalidated++
+	return p.result, nil
+}
+
+func TestRevalidateItineraryRouting(t *testing.T) {
+	const itineraryID = "0123456789abcdef0123"
+	tests := []struct {
+		name    string
+		source  string // Source of the offer kept under the itinerary ID; none to select the flights in full
+		want    string // Provider expected to revalidate
+		wantErr error
+	}{
+		{name: "offer goes to the PCC that found it", source: "sabre:B", want: "sabre:B"},
+		{name: "offer of the default provider", source: "sabre:A", want: "sabre:A"},
+		{name: "selection without an ID goes to the default provider", want: "sabre:A"},
+		{name: "provider that cannot revalidate", source: "amadeus", wantErr: domain.ErrNotSupported},
+		{name: "provider no longer configured", source: "sabre:C", wantErr: domain.ErrNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sabreA := &stubRevalidator{stubProvider: stubProvider{name: "sabre:A"}}
+			sabreB := &stubRevalidator{stubProvider: stubProvider{name: "sabre:B"}}
+			for _, p := range []*stubRevalidator{sabreA, sabreB} {
+				p.result = &domain.SearchResult{Offers: []domain.Offer{testOffer(p.name, "DL", 400, 300, "USD")}}
+			}
+			service := &FlightService{
+				Providers:   []interfaces.FlightProvider{sabreA, sabreB, &stubProvider{name: "amadeus"}},
+				Revalidator: sabreA,
+				Offers:      snapshots.NewMemoryStore(time.Minute),
+			}
+
+			req := &DTO.RevalidateRequest{}
+			if tt.source != "" {
+				offer := testOffer(tt.source, "DL", 400, 300, "USD")
+				offer.ItineraryID = itineraryID
+				snapshot := &domain.OfferSnapshot{Offer: offer, Passengers: []domain.PassengerCount{{Type: domain.PassengerAdult, Count: 1}}}
+				if err := service.Offers.Save(context.Background(), snapshot); err != nil {
+					t.Fatalf("saving offer: %v", err)
+				}
+				req.OfferID = itineraryID
+			}
+
+			_, err := service.RevalidateItinerary(context.Background(), req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if sabreA.revalidated+sabreB.revalidated != 0 {
+					t.Error("offer was revalidated by another provider")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := map[string]int{"sabre:A": sabreA.revalidated, "sabre:B": sabreB.revalidated}
+			if got[tt.want] != 1 || sabreA.revalidated+sabreB.revalidated != 1 {
+				t.Errorf("revalidations = %v, want one by %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package use_case
+
+import (
+	"context"
+
+	"github.com/Yordi-SE/FlightSearch/domain"
+	DTO "github.com/Yordi-SE/FlightSearch/use_case/dto"
+)
+
+// GetSeatMap returns the seat layout of a segment picked from the search results
+// Args:
+//
+//	ctx - Context controlling the lifetime of the request
+//	req - The segment the seat map is requested for
+//
+// Returns:
+//
+//	Pointer to SeatMapResponse with the cabin layout or an error if none is available
+func (s *FlightService) GetSeatMap(ctx context.Context, req *DTO.SeatMapRequest) (*DTO.SeatMapResponse, error) {
+	if s.SeatMaps == nil {
+		return nil, domain.ErrNotSupported
+	}
+
+	seatMap, err := s.SeatMaps.SeatMap(ctx, req.ToSeatMapQuery())
+	if err != nil {
+		return nil, err
+	}
+	return &DTO.SeatMapResponse{SeatMap: seatMap}, nil
+}